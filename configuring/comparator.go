@@ -0,0 +1,199 @@
+package configuring
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var comparatorRegistry = struct {
+	sync.RWMutex
+	compareFns map[reflect.Type]reflect.Value
+	equalFns   map[reflect.Type]reflect.Value
+}{
+	compareFns: map[reflect.Type]reflect.Value{},
+	equalFns:   map[reflect.Type]reflect.Value{},
+}
+
+// RegisterComparator registers comparator function of type 'func(a, b T) int' for all configurators.
+// Comparator should return -1 if a < b, 0 if a == b and 1 if a > b.
+// Registered comparator takes precedence over comparison methods of T and replaces previously registered comparator of T.
+func RegisterComparator(compareFn interface{}) {
+	rCompareFn := checkComparatorFn(compareFn)
+	comparatorRegistry.Lock()
+	defer comparatorRegistry.Unlock()
+	comparatorRegistry.compareFns[rCompareFn.Type().In(0)] = rCompareFn
+}
+
+// RegisterEqualer registers equality function of type 'func(a, b T) bool' for all configurators.
+// Registered equaler takes precedence over 'Equal' method of T and replaces previously registered equaler of T.
+func RegisterEqualer(equalFn interface{}) {
+	rEqualFn := checkEqualerFn(equalFn)
+	comparatorRegistry.Lock()
+	defer comparatorRegistry.Unlock()
+	comparatorRegistry.equalFns[rEqualFn.Type().In(0)] = rEqualFn
+}
+
+// WithComparator provides comparator function of type 'func(a, b T) int' for values of type T.
+// Comparator should return -1 if a < b, 0 if a == b and 1 if a > b.
+// It takes precedence over registered comparators and comparison methods and is used for equality if no equaler of T provided.
+func (c Configurator) WithComparator(compareFn interface{}) Configurator {
+	var compareFns []reflect.Value
+	compareFns = append(compareFns, checkComparatorFn(compareFn))
+	compareFns = append(compareFns, c.compareFns...)
+	c.compareFns = compareFns
+	return c
+}
+
+// WithEqualer provides equality function of type 'func(a, b T) bool' for values of type T.
+// It takes precedence over registered equalers and 'Equal' method.
+func (c Configurator) WithEqualer(equalFn interface{}) Configurator {
+	var equalFns []reflect.Value
+	equalFns = append(equalFns, checkEqualerFn(equalFn))
+	equalFns = append(equalFns, c.equalFns...)
+	c.equalFns = equalFns
+	return c
+}
+
+func checkComparatorFn(compareFn interface{}) reflect.Value {
+	rCompareFn := reflect.ValueOf(compareFn)
+	if rCompareFn.Kind() != reflect.Func || rCompareFn.IsNil() || !isBinaryFn(rCompareFn.Type(), reflect.TypeOf(int(0))) {
+		panic(fmt.Errorf("invalid comparator function type '%T' (type should be 'func(T, T) int')", compareFn))
+	}
+	return rCompareFn
+}
+
+func checkEqualerFn(equalFn interface{}) reflect.Value {
+	rEqualFn := reflect.ValueOf(equalFn)
+	if rEqualFn.Kind() != reflect.Func || rEqualFn.IsNil() || !isBinaryFn(rEqualFn.Type(), reflect.TypeOf(bool(false))) {
+		panic(fmt.Errorf("invalid equaler function type '%T' (type should be 'func(T, T) bool')", equalFn))
+	}
+	return rEqualFn
+}
+
+func isBinaryFn(rFnType reflect.Type, rResultType reflect.Type) bool {
+	return !rFnType.IsVariadic() && rFnType.NumIn() == 2 && rFnType.In(0) == rFnType.In(1) && rFnType.NumOut() == 1 && rFnType.Out(0) == rResultType
+}
+
+func findFn(fns []reflect.Value, rType reflect.Type) (reflect.Value, bool) {
+	for _, fn := range fns {
+		if fn.Type().In(0) == rType {
+			return fn, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func findRegisteredFn(fns map[reflect.Type]reflect.Value, rType reflect.Type) (reflect.Value, bool) {
+	comparatorRegistry.RLock()
+	defer comparatorRegistry.RUnlock()
+	fn, found := fns[rType]
+	return fn, found
+}
+
+// findPairFn finds function for target type or for types of indirected pair.
+// Functions are not found if target or value is nil interface.
+func findPairFn(find func(rType reflect.Type) (reflect.Value, bool), target interface{}, value interface{}) (reflect.Value, reflect.Value, reflect.Value, bool) {
+	rTarget, rValue := reflect.ValueOf(target), reflect.ValueOf(value)
+	if !rTarget.IsValid() || !rValue.IsValid() {
+		return reflect.Value{}, reflect.Value{}, reflect.Value{}, false
+	}
+	if fn, found := find(rTarget.Type()); found {
+		return fn, rTarget, rValue, true
+	}
+	if rTarget.Kind() == reflect.Ptr {
+		rTarget, rValue = indirectPair(target, value)
+		if rTarget.IsValid() && rValue.IsValid() && rTarget.Kind() != reflect.Ptr {
+			if fn, found := find(rTarget.Type()); found {
+				return fn, rTarget, rValue, true
+			}
+		}
+	}
+	return reflect.Value{}, reflect.Value{}, reflect.Value{}, false
+}
+
+func compareByRegistry(target interface{}, value interface{}) (int, bool) {
+	find := func(rType reflect.Type) (reflect.Value, bool) {
+		return findRegisteredFn(comparatorRegistry.compareFns, rType)
+	}
+	if fn, rTarget, rValue, found := findPairFn(find, target, value); found {
+		return normalizeComparison(fn.Call([]reflect.Value{rTarget, rValue})[0].Int()), true
+	}
+	return 0, false
+}
+
+func equalByRegistry(target interface{}, value interface{}) (bool, bool) {
+	find := func(rType reflect.Type) (reflect.Value, bool) {
+		return findRegisteredFn(comparatorRegistry.equalFns, rType)
+	}
+	if fn, rTarget, rValue, found := findPairFn(find, target, value); found {
+		return fn.Call([]reflect.Value{rTarget, rValue})[0].Bool(), true
+	}
+	return false, false
+}
+
+func normalizeComparison(result int64) int {
+	if result < 0 {
+		return -1
+	}
+	if result > 0 {
+		return 1
+	}
+	return 0
+}
+
+func (c Configurator) compare(target interface{}, value interface{}) (int, error) {
+	if c.comparison != nil && reflect.TypeOf(target) == c.comparison.rType {
+		return c.comparison.compareFn(target, value)
+	}
+	find := func(rType reflect.Type) (reflect.Value, bool) {
+		return findFn(c.compareFns, rType)
+	}
+	if fn, rTarget, rValue, found := findPairFn(find, target, value); found {
+		return normalizeComparison(fn.Call([]reflect.Value{rTarget, rValue})[0].Int()), nil
+	}
+	return compare(target, value)
+}
+
+// hasComparator returns true if values of type are compared with comparator of c or registered comparator.
+func (c Configurator) hasComparator(rType reflect.Type) bool {
+	if c.comparison != nil && c.comparison.rType == rType {
+		return true
+	}
+	if _, found := findFn(c.compareFns, rType); found {
+		return true
+	}
+	_, found := findRegisteredFn(comparatorRegistry.compareFns, rType)
+	return found
+}
+
+func (c Configurator) equal(target interface{}, value interface{}) bool {
+	if c.comparison != nil && reflect.TypeOf(target) == c.comparison.rType {
+		return c.comparison.equalFn(target, value)
+	}
+	if len(c.equalFns) > 0 {
+		find := func(rType reflect.Type) (reflect.Value, bool) {
+			return findFn(c.equalFns, rType)
+		}
+		if fn, rTarget, rValue, found := findPairFn(find, target, value); found {
+			return fn.Call([]reflect.Value{rTarget, rValue})[0].Bool()
+		}
+	}
+	if len(c.compareFns) > 0 {
+		find := func(rType reflect.Type) (reflect.Value, bool) {
+			return findFn(c.compareFns, rType)
+		}
+		if fn, rTarget, rValue, found := findPairFn(find, target, value); found {
+			return fn.Call([]reflect.Value{rTarget, rValue})[0].Int() == 0
+		}
+	}
+	return equal(target, value)
+}
+
+func (c Configurator) hasEqual(target interface{}, values []interface{}) bool {
+	var equalFound bool
+	for _, value := range values {
+		equalFound = equalFound || c.equal(target, value)
+	}
+	return equalFound
+}
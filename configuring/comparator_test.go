@@ -0,0 +1,127 @@
+package configuring
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type testVersion struct {
+	Major int
+	Minor int
+}
+
+func compareTestVersions(v1 testVersion, v2 testVersion) int {
+	if v1.Major != v2.Major {
+		return v1.Major - v2.Major
+	}
+	return v1.Minor - v2.Minor
+}
+
+func TestConfigurator_WithComparator(t *testing.T) {
+	calls := 0
+	err := NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		if message := fmt.Sprintf(format, args...); message != "configuration: min: '{1 2}' max: '{2 0}' input: '{1 10}' output: '{1 10}'" {
+			t.Errorf("expected '%v', was '%v'", "configuration: min: '{1 2}' max: '{2 0}' input: '{1 10}' output: '{1 10}'", message)
+		}
+		calls = calls + 1
+	}).WithComparator(compareTestVersions).WithMin(testVersion{1, 2}).WithMax(testVersion{2, 0}).Configure(&testVersion{1, 10})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithComparator(compareTestVersions).WithMin(&testVersion{1, 2}).Validate(&testVersion{1, 1})
+	if err == nil || err.Error() != "validation error: argument should be greater than or equal to '&{1 2}'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be greater than or equal to '&{1 2}'", err)
+	}
+	err = NewConfigurator().WithComparator(compareTestVersions).WithAllowed(testVersion{1, 2}).Validate(testVersion{1, 2})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithMin(testVersion{1, 2}).Validate(testVersion{1, 1})
+	if err == nil || err.Error() != "validation error: invalid min value: argument of type 'configuring.testVersion' can not be lower than or greater than value of type 'configuring.testVersion'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: invalid min value: argument of type 'configuring.testVersion' can not be lower than or greater than value of type 'configuring.testVersion'", err)
+	}
+	err = NewConfigurator().WithComparator(compareTestVersions).WithElementConfigurator(NewConfigurator().WithMin(testVersion{1, 0})).Configure(&[]testVersion{{1, 0}})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	func() {
+		defer func() {
+			if rerr := recover(); rerr == nil || fmt.Sprint(rerr) != "invalid comparator function type 'func(int, string) int' (type should be 'func(T, T) int')" {
+				t.Errorf("expected '%v', was '%v'", "invalid comparator function type 'func(int, string) int' (type should be 'func(T, T) int')", rerr)
+			}
+		}()
+		NewConfigurator().WithComparator(func(int, string) int { return 0 })
+	}()
+	if calls != 1 {
+		t.Errorf("expected '%v', was '%v'", 1, calls)
+	}
+}
+
+func TestConfigurator_WithComparator_Keys(t *testing.T) {
+	reversed := func(v1 string, v2 string) int {
+		return strings.Compare(v2, v1)
+	}
+	err := NewConfigurator().WithComparator(reversed).WithKeyValidators(NewConfigurator().WithAllowed("c")).Validate(map[string]int{"a": 1, "b": 2})
+	if err == nil || err.Error() != "validation error: argument key 'b' should be validated with validator at index '0': validation error: argument should be in allowed values ['c']" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument key 'b' should be validated with validator at index '0': validation error: argument should be in allowed values ['c']", err)
+	}
+	err = NewConfigurator().WithComparator(reversed).WithElementValidators(NewConfigurator().WithMax(0)).Validate(map[string]int{"a": 1, "b": 2})
+	if err == nil || err.Error() != "validation error: argument element with key 'b' should be validated with validator at index '0': validation error: argument should be lower than or equal to '0'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument element with key 'b' should be validated with validator at index '0': validation error: argument should be lower than or equal to '0'", err)
+	}
+}
+
+func TestConfigurator_WithEqualer(t *testing.T) {
+	err := NewConfigurator().WithEqualer(strings.EqualFold).WithAllowed("debug", "info").Validate("INFO")
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithAllowed("debug", "info").Validate("INFO")
+	if err == nil || err.Error() != "validation error: argument should be in allowed values ['debug','info']" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be in allowed values ['debug','info']", err)
+	}
+	func() {
+		defer func() {
+			if rerr := recover(); rerr == nil || fmt.Sprint(rerr) != "invalid equaler function type '<nil>' (type should be 'func(T, T) bool')" {
+				t.Errorf("expected '%v', was '%v'", "invalid equaler function type '<nil>' (type should be 'func(T, T) bool')", rerr)
+			}
+		}()
+		NewConfigurator().WithEqualer(nil)
+	}()
+}
+
+func TestConfigurator_WithEqualer_NilInterface(t *testing.T) {
+	var value interface{}
+	err := NewConfigurator().WithEqualer(strings.EqualFold).WithAllowed("a").Configure(&value)
+	if err == nil || err.Error() != "configuration error: target value error: argument should be in allowed values ['a']" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: target value error: argument should be in allowed values ['a']", err)
+	}
+	err = NewConfigurator().WithComparator(strings.Compare).WithDefault("x").WithLogChangesOnly(true).Configure(&value)
+	if err != nil || value != "x" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "x", value, err)
+	}
+}
+
+type testRegisteredVersion testVersion
+
+func TestRegisterComparator(t *testing.T) {
+	RegisterComparator(func(v1 testRegisteredVersion, v2 testRegisteredVersion) int {
+		return compareTestVersions(testVersion(v1), testVersion(v2))
+	})
+	RegisterEqualer(func(v1 testRegisteredVersion, v2 testRegisteredVersion) bool {
+		return v1.Major == v2.Major
+	})
+	err := NewConfigurator().WithMax(testRegisteredVersion{2, 0}).Validate(testRegisteredVersion{2, 1})
+	if err == nil || err.Error() != "validation error: argument should be lower than or equal to '{2 0}'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be lower than or equal to '{2 0}'", err)
+	}
+	err = NewConfigurator().WithAllowed(testRegisteredVersion{2, 0}).Validate(testRegisteredVersion{2, 1})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithComparator(func(v1 testRegisteredVersion, v2 testRegisteredVersion) int { return 0 }).WithMax(testRegisteredVersion{2, 0}).Validate(testRegisteredVersion{2, 1})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+}
@@ -0,0 +1,344 @@
+package configuring
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Plan is a configurator compiled for values of a single type.
+// It validates and configures values of that type without converting bounds, allowed, disallowed and default values
+// and without discovering comparison methods on every call. Default, current and fallback values of pointer, slice and map types
+// are converted on every configuration like with Configurator.Configure, so values allocated by conversion
+// (e.g. pointers to default values) are not shared by configured targets. Values of other types are processed by the source configurator.
+type Plan struct {
+	source       Configurator
+	configurator Configurator
+	rTargetType  reflect.Type
+	isOptional   bool
+	// rReferenceType is type of values of reference kind (e.g. pointers) which default, current and fallback values
+	// are converted to on every configuration, so configured targets do not share values (nil for other types).
+	rReferenceType reflect.Type
+}
+
+// comparison holds comparison functions compiled for values of a single type.
+type comparison struct {
+	rType     reflect.Type
+	compareFn func(target interface{}, value interface{}) (int, error)
+	equalFn   func(target interface{}, value interface{}) bool
+}
+
+// Compile prepares plan for values of target type (e.g. reflect.TypeOf(time.Duration(0))).
+// Comparators, equalers and comparison methods are resolved on compilation,
+// so comparators and equalers registered after compilation are not used by the plan.
+// Configurators provided as length, key and element validators are compiled too.
+// Plans of Optional types are compiled for pointers to values of optionals.
+func (c Configurator) Compile(rTargetType reflect.Type) (Plan, error) {
+	if rTargetType == nil {
+		return Plan{}, c.wrapError("compilation", c.errorf(MessageNotNil, MessageData{}, "argument should not be nil"))
+	}
+	if rTargetType.Kind() == reflect.Interface {
+		return Plan{}, c.wrapError("compilation", fmt.Errorf("argument of type '%v' should not be an interface", rTargetType.String()))
+	}
+	rValueType, isOptional := rTargetType, rTargetType.Implements(optionalType)
+	if isOptional {
+		rValueType = optionalValueType(rTargetType)
+	}
+	compiled, err := c.convert(reflect.Zero(rValueType).Interface())
+	if err != nil {
+		return Plan{}, c.wrapError("compilation", err)
+	}
+	compiled.isOptional = compiled.isOptional || isOptional
+	rIndirectType := rValueType
+	for rIndirectType.Kind() == reflect.Ptr {
+		rIndirectType = rIndirectType.Elem()
+	}
+	if compiled.lengthValidators, err = compileValidators(compiled.lengthValidators, reflect.TypeOf(int(0))); err != nil {
+		return Plan{}, c.wrapError("compilation", fmt.Errorf("invalid length validators: %v", err))
+	}
+	if rIndirectType.Kind() == reflect.Map {
+		if compiled.keyValidators, err = compileValidators(compiled.keyValidators, rIndirectType.Key()); err != nil {
+			return Plan{}, c.wrapError("compilation", fmt.Errorf("invalid key validators: %v", err))
+		}
+	}
+	rElementType := reflect.TypeOf(rune(0))
+	switch rIndirectType.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		rElementType = rIndirectType.Elem()
+	}
+	if compiled.elementValidators, err = compileValidators(compiled.elementValidators, rElementType); err != nil {
+		return Plan{}, c.wrapError("compilation", fmt.Errorf("invalid element validators: %v", err))
+	}
+	compiled.comparison = c.compileComparison(rValueType)
+	plan := Plan{source: c, configurator: compiled, rTargetType: rTargetType, isOptional: isOptional}
+	switch rValueType.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		plan.rReferenceType = rValueType
+	}
+	return plan, nil
+}
+
+// compileValidators compiles configurators of validators for values of target type.
+func compileValidators(validators []Validator, rTargetType reflect.Type) ([]Validator, error) {
+	if len(validators) == 0 || rTargetType.Kind() == reflect.Interface {
+		return validators, nil
+	}
+	result := make([]Validator, len(validators))
+	for i, validator := range validators {
+		result[i] = validator
+		if configurator, ok := validator.(Configurator); ok {
+			plan, err := configurator.Compile(rTargetType)
+			if err != nil {
+				return nil, fmt.Errorf("invalid validator at index '%v': %v", i, err)
+			}
+			result[i] = plan
+		}
+	}
+	return result, nil
+}
+
+// Validate validates target like Configurator.Validate.
+func (p Plan) Validate(target interface{}) error {
+	if reflect.TypeOf(target) != p.rTargetType {
+		return p.source.Validate(target)
+	}
+	if p.isOptional {
+		if target = getValue(target.(optional).optionalPointer()); isNil(target) {
+			return nil
+		}
+	}
+	if len(p.configurator.normalizers) > 0 {
+		var err error
+		if target, err = p.configurator.normalize(target); err != nil {
+			return p.configurator.wrapError("validation", err)
+		}
+	}
+	return p.configurator.wrapValidationError(p.configurator.validate(target))
+}
+
+// ValidateContext validates target like Validate with provided context.
+func (p Plan) ValidateContext(ctx context.Context, target interface{}) error {
+	p.source.ctx, p.configurator.ctx = ctx, ctx
+	return p.Validate(target)
+}
+
+// Configure configures target like Configurator.Configure.
+func (p Plan) Configure(targetPointer interface{}) error {
+	if err := beConfigurable(targetPointer); err != nil || reflect.TypeOf(targetPointer).Elem() != p.rTargetType {
+		return p.source.Configure(targetPointer)
+	}
+	configurator, err := p.configuredValues()
+	if err != nil {
+		return configurator.wrapError("configuration", err)
+	}
+	if p.isOptional {
+		optionalPointer := targetPointer.(optionalSetter)
+		configurator, valuePointer := configurator.unwrapOptional(optionalPointer)
+		if err := configurator.configurePointer(valuePointer, getValue(valuePointer), nil); err != nil {
+			return err
+		}
+		optionalPointer.setOptionalPointer(valuePointer)
+		return nil
+	}
+	return configurator.configurePointer(targetPointer, getValue(targetPointer), nil)
+}
+
+// configuredValues returns configurator of plan with default, current and fallback values of source converted again
+// for values of reference kind like Configurator.Configure converts them on every call.
+func (p Plan) configuredValues() (Configurator, error) {
+	c := p.configurator
+	if p.rReferenceType == nil {
+		return c, nil
+	}
+	target := reflect.Zero(p.rReferenceType).Interface()
+	var err error
+	if c.currentValue, err = convertNotNil(target, p.source.currentValue); err != nil {
+		return c, c.errorf(MessageInvalidCurrent, MessageData{Error: err.Error()}, "invalid current value: %v", err)
+	}
+	if c.defaultValue, err = convertNotNil(target, p.source.defaultValue); err != nil {
+		return c, c.errorf(MessageInvalidDefault, MessageData{Error: err.Error()}, "invalid default value: %v", err)
+	}
+	if c.fallbackValues, err = convertArray(target, p.source.fallbackValues); err != nil {
+		return c, c.errorf(MessageInvalidFallbacks, MessageData{Error: err.Error()}, "invalid fallback values: %v", err)
+	}
+	return c, nil
+}
+
+// compileComparison resolves comparison functions for values of target type in order of Configurator.compare and Configurator.equal.
+// Pointer types are not compiled because comparison of pointers depends on nil values.
+func (c Configurator) compileComparison(rType reflect.Type) *comparison {
+	if rType.Kind() == reflect.Ptr {
+		return nil
+	}
+	return &comparison{
+		rType:     rType,
+		compareFn: c.compileCompare(rType),
+		equalFn:   c.compileEqual(rType),
+	}
+}
+
+func (c Configurator) compileCompare(rType reflect.Type) func(target interface{}, value interface{}) (int, error) {
+	if fn, found := findFn(c.compareFns, rType); found {
+		return compileComparatorFn(fn)
+	}
+	if fn, found := findRegisteredFn(comparatorRegistry.compareFns, rType); found {
+		return compileComparatorFn(fn)
+	}
+	for _, methodName := range CompareMethodNames {
+		if methodFn, err := findMethod(methodName, rType, reflect.TypeOf(int(0))); err == nil {
+			return func(target interface{}, value interface{}) (int, error) {
+				return normalizeComparison(methodFn(reflect.ValueOf(target), reflect.ValueOf(value)).Int()), nil
+			}
+		}
+	}
+	for _, methodName := range LowerMethodNames {
+		if methodFn, err := findMethod(methodName, rType, reflect.TypeOf(bool(true))); err == nil {
+			return compileLowerFn(func(rValue1 reflect.Value, rValue2 reflect.Value) bool {
+				return methodFn(rValue1, rValue2).Bool()
+			})
+		}
+	}
+	for _, methodName := range GreaterMethodNames {
+		if methodFn, err := findMethod(methodName, rType, reflect.TypeOf(bool(true))); err == nil {
+			return compileLowerFn(func(rValue1 reflect.Value, rValue2 reflect.Value) bool {
+				return methodFn(rValue2, rValue1).Bool()
+			})
+		}
+	}
+	switch getNumberKind(rType.Kind()) {
+	case reflect.Int64:
+		return func(target interface{}, value interface{}) (int, error) {
+			return compareInt64Values(reflect.ValueOf(target).Int(), reflect.ValueOf(value).Int()), nil
+		}
+	case reflect.Uint64:
+		return func(target interface{}, value interface{}) (int, error) {
+			return compareUint64Values(reflect.ValueOf(target).Uint(), reflect.ValueOf(value).Uint()), nil
+		}
+	case reflect.Float64:
+		return func(target interface{}, value interface{}) (int, error) {
+			return compareFloat64Values(reflect.ValueOf(target).Float(), reflect.ValueOf(value).Float()), nil
+		}
+	}
+	if rType.Kind() == reflect.String {
+		return func(target interface{}, value interface{}) (int, error) {
+			return compareStringValues(reflect.ValueOf(target).String(), reflect.ValueOf(value).String()), nil
+		}
+	}
+	return compare
+}
+
+func (c Configurator) compileEqual(rType reflect.Type) func(target interface{}, value interface{}) bool {
+	if fn, found := findFn(c.equalFns, rType); found {
+		return compileEqualerFn(fn)
+	}
+	if fn, found := findFn(c.compareFns, rType); found {
+		return compileEqualByComparatorFn(fn)
+	}
+	if fn, found := findRegisteredFn(comparatorRegistry.equalFns, rType); found {
+		return compileEqualerFn(fn)
+	}
+	if fn, found := findRegisteredFn(comparatorRegistry.compareFns, rType); found {
+		return compileEqualByComparatorFn(fn)
+	}
+	for _, methodName := range EqualMethodNames {
+		if methodFn, err := findMethod(methodName, rType, reflect.TypeOf(bool(true))); err == nil {
+			return func(target interface{}, value interface{}) bool {
+				return methodFn(reflect.ValueOf(target), reflect.ValueOf(value)).Bool()
+			}
+		}
+	}
+	for _, methodName := range CompareMethodNames {
+		if methodFn, err := findMethod(methodName, rType, reflect.TypeOf(int(0))); err == nil {
+			return func(target interface{}, value interface{}) bool {
+				return methodFn(reflect.ValueOf(target), reflect.ValueOf(value)).Int() == 0
+			}
+		}
+	}
+	if getNumberKind(rType.Kind()) != reflect.Invalid || rType.Kind() == reflect.String || rType.Kind() == reflect.Bool {
+		return func(target interface{}, value interface{}) bool {
+			return target == value
+		}
+	}
+	if rType.Comparable() {
+		return func(target interface{}, value interface{}) bool {
+			return target == value || reflect.DeepEqual(target, value)
+		}
+	}
+	return reflect.DeepEqual
+}
+
+func compileComparatorFn(fn reflect.Value) func(target interface{}, value interface{}) (int, error) {
+	return func(target interface{}, value interface{}) (int, error) {
+		return normalizeComparison(fn.Call([]reflect.Value{reflect.ValueOf(target), reflect.ValueOf(value)})[0].Int()), nil
+	}
+}
+
+func compileEqualerFn(fn reflect.Value) func(target interface{}, value interface{}) bool {
+	return func(target interface{}, value interface{}) bool {
+		return fn.Call([]reflect.Value{reflect.ValueOf(target), reflect.ValueOf(value)})[0].Bool()
+	}
+}
+
+func compileEqualByComparatorFn(fn reflect.Value) func(target interface{}, value interface{}) bool {
+	return func(target interface{}, value interface{}) bool {
+		return fn.Call([]reflect.Value{reflect.ValueOf(target), reflect.ValueOf(value)})[0].Int() == 0
+	}
+}
+
+// compileLowerFn returns comparison by lower function, which falls back to generic comparison if both values are lower than each other.
+func compileLowerFn(lowerFn func(rValue1 reflect.Value, rValue2 reflect.Value) bool) func(target interface{}, value interface{}) (int, error) {
+	return func(target interface{}, value interface{}) (int, error) {
+		rTarget, rValue := reflect.ValueOf(target), reflect.ValueOf(value)
+		lower, greater := lowerFn(rTarget, rValue), lowerFn(rValue, rTarget)
+		if lower && greater {
+			return compare(target, value)
+		}
+		if lower {
+			return -1, nil
+		}
+		if greater {
+			return 1, nil
+		}
+		return 0, nil
+	}
+}
+
+func compareInt64Values(value1 int64, value2 int64) int {
+	if value1 > value2 {
+		return 1
+	}
+	if value1 < value2 {
+		return -1
+	}
+	return 0
+}
+
+func compareUint64Values(value1 uint64, value2 uint64) int {
+	if value1 > value2 {
+		return 1
+	}
+	if value1 < value2 {
+		return -1
+	}
+	return 0
+}
+
+func compareFloat64Values(value1 float64, value2 float64) int {
+	if value1 > value2 {
+		return 1
+	}
+	if value1 < value2 {
+		return -1
+	}
+	return 0
+}
+
+func compareStringValues(value1 string, value2 string) int {
+	if value1 > value2 {
+		return 1
+	}
+	if value1 < value2 {
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,164 @@
+package configuring
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfigurator_Compile(t *testing.T) {
+	plan, err := NewConfigurator().WithName("Timeout").WithMin("1s").WithMax(time.Minute).WithDefault(5 * time.Second).Compile(reflect.TypeOf(time.Duration(0)))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = plan.Validate(time.Second)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = plan.Validate(time.Hour)
+	if err == nil || err.Error() != "validation of 'Timeout' error: argument should be lower than or equal to '1m0s'" {
+		t.Errorf("expected '%v', was '%v'", "validation of 'Timeout' error: argument should be lower than or equal to '1m0s'", err)
+	}
+	err = plan.Validate(int64(time.Hour))
+	if err == nil || err.Error() != "validation of 'Timeout' error: invalid min value: argument of type 'string' should be convertible to type 'int64'" {
+		t.Errorf("expected '%v', was '%v'", "validation of 'Timeout' error: invalid min value: argument of type 'string' should be convertible to type 'int64'", err)
+	}
+	timeout := time.Hour
+	err = plan.Configure(&timeout)
+	if err != nil || timeout != 5*time.Second {
+		t.Errorf("expected '%v', was '%v' ('%v')", 5*time.Second, timeout, err)
+	}
+	err = plan.Configure(timeout)
+	if err == nil || err.Error() != "configuration of 'Timeout' error: target value is not configurable: argument of type 'time.Duration' should be a pointer" {
+		t.Errorf("expected '%v', was '%v'", "configuration of 'Timeout' error: target value is not configurable: argument of type 'time.Duration' should be a pointer", err)
+	}
+	versionPlan, err := NewConfigurator().WithComparator(compareTestVersions).WithMin(testVersion{1, 2}).WithAllowed(testVersion{1, 2}, testVersion{2, 0}).Compile(reflect.TypeOf(testVersion{}))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = versionPlan.Validate(testVersion{2, 0})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = versionPlan.Validate(testVersion{1, 5})
+	if err == nil || err.Error() != "validation error: argument should be in allowed values ['{1 2}','{2 0}']" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be in allowed values ['{1 2}','{2 0}']", err)
+	}
+	timePlan, err := NewConfigurator().WithMax(time.Unix(10, 0)).Compile(reflect.TypeOf(time.Time{}))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = timePlan.Validate(time.Unix(11, 0))
+	if err == nil {
+		t.Errorf("expected '%v', was '%v'", "validation error", err)
+	}
+	listPlan, err := NewConfigurator().WithLengthValidators(NewConfigurator().WithMax(2)).WithElementValidators(NewConfigurator().WithMin(1)).Compile(reflect.TypeOf([]int(nil)))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = listPlan.Validate([]int{1, 0})
+	if err == nil || err.Error() != "validation error: argument element at index '1' should be validated with validator at index '0': validation error: argument should be greater than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument element at index '1' should be validated with validator at index '0': validation error: argument should be greater than or equal to '1'", err)
+	}
+	_, err = NewConfigurator().WithElementValidators(NewConfigurator().WithMin("a")).Compile(reflect.TypeOf([]int(nil)))
+	if err == nil || err.Error() != "compilation error: invalid element validators: invalid validator at index '0': compilation error: invalid min value: argument of type 'string' should be convertible to type 'int'" {
+		t.Errorf("expected '%v', was '%v'", "compilation error: invalid element validators: invalid validator at index '0': compilation error: invalid min value: argument of type 'string' should be convertible to type 'int'", err)
+	}
+	_, err = NewConfigurator().Compile(reflect.TypeOf((*Validator)(nil)).Elem())
+	if err == nil || err.Error() != "compilation error: argument of type 'configuring.Validator' should not be an interface" {
+		t.Errorf("expected '%v', was '%v'", "compilation error: argument of type 'configuring.Validator' should not be an interface", err)
+	}
+	_, err = NewConfigurator().Compile(nil)
+	if err == nil || err.Error() != "compilation error: argument should not be nil" {
+		t.Errorf("expected '%v', was '%v'", "compilation error: argument should not be nil", err)
+	}
+}
+
+func newBenchmarkConfigurator() Configurator {
+	return NewConfigurator().WithName("Timeout").WithMin(time.Second).WithMax(time.Minute).WithAllowed(time.Second, 5*time.Second, 10*time.Second).WithDefault(5 * time.Second)
+}
+
+func TestPlan_Configure_Pointers(t *testing.T) {
+	plan, err := NewConfigurator().WithDefault(5).Compile(reflect.TypeOf((*int)(nil)))
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	var a, b *int
+	if err := plan.Configure(&a); err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if err := plan.Configure(&b); err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if a == nil || b == nil || a == b {
+		t.Fatalf("expected '%v', was '%v'", "distinct pointers", []*int{a, b})
+	}
+	*a = 42
+	if *b != 5 {
+		t.Errorf("expected '%v', was '%v'", 5, *b)
+	}
+	plan, err = NewConfigurator().WithFallbacks("5s").Compile(reflect.TypeOf((*time.Duration)(nil)))
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	var timeout1, timeout2 *time.Duration
+	if err := plan.Configure(&timeout1); err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if err := plan.Configure(&timeout2); err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if timeout1 == nil || timeout2 == nil || timeout1 == timeout2 || *timeout2 != 5*time.Second {
+		t.Errorf("expected '%v', was '%v'", "distinct pointers", []*time.Duration{timeout1, timeout2})
+	}
+}
+
+func BenchmarkConfigurator_Validate(b *testing.B) {
+	configurator := newBenchmarkConfigurator()
+	var target interface{} = 10 * time.Second
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := configurator.Validate(target); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPlan_Validate(b *testing.B) {
+	plan, err := newBenchmarkConfigurator().Compile(reflect.TypeOf(time.Duration(0)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	var target interface{} = 10 * time.Second
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := plan.Validate(target); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkConfigurator_Configure(b *testing.B) {
+	configurator := newBenchmarkConfigurator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		timeout := time.Hour
+		if err := configurator.Configure(&timeout); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPlan_Configure(b *testing.B) {
+	plan, err := newBenchmarkConfigurator().Compile(reflect.TypeOf(time.Duration(0)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		timeout := time.Hour
+		if err := plan.Configure(&timeout); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"strings"
+	"text/template"
 )
 
 var (
@@ -16,23 +18,44 @@ type Validator interface {
 	Validate(target interface{}) error
 }
 
+// ContextValidator is validator receiving context of configurator.
+// Configurator calls ValidateContext instead of Validate for validators implementing it.
+type ContextValidator interface {
+	ValidateContext(ctx context.Context, target interface{}) error
+}
+
 type Configurator struct {
 	ctx            context.Context
 	name           string
+	scope          string
 	logFn          interface{}
 	logChangesOnly bool
 	logValueFormat string
 
-	minValue          interface{}
-	maxValue          interface{}
-	allowedValues     []interface{}
-	disallowedValues  []interface{}
-	defaultValue      interface{}
-	currentValue      interface{}
-	targetValidators  []Validator
-	lengthValidators  []Validator
-	elementValidators []Validator
-	isSecret          bool
+	minValue            interface{}
+	maxValue            interface{}
+	allowedValues       []interface{}
+	disallowedValues    []interface{}
+	defaultValue        interface{}
+	defaultFn           func(ctx context.Context) (interface{}, error)
+	fallbackValues      []interface{}
+	currentValue        interface{}
+	targetValidators    []Validator
+	lengthValidators    []Validator
+	keyValidators       []Validator
+	elementValidators   []Validator
+	elementConfigurator *Configurator
+	normalizers         []Normalizer
+	advisories          []advisory
+	compareFns          []reflect.Value
+	equalFns            []reflect.Value
+	comparison          *comparison
+	parallelism         int
+	locale              string
+	messages            map[string]*template.Template
+	isSecret            bool
+	isDryRun            bool
+	isOptional          bool
 }
 
 func NewConfigurator() Configurator {
@@ -45,15 +68,20 @@ func NewConfigurator() Configurator {
 // func(ctx context.Context, args ...interface{})
 // func(format string, args ...interface{})
 // func(args ...interface{})
+// func(ctx context.Context, severity Severity, format string, args ...interface{})
+// func(severity Severity, format string, args ...interface{})
+// Leveled loggers receive configuration messages with SeverityInfo and messages of advisory rules with their severity.
 func (c Configurator) WithLogger(logFn interface{}) Configurator {
 	switch logFn := logFn.(type) {
+	case func(context.Context, Severity, string, ...interface{}):
+	case func(Severity, string, ...interface{}):
 	case func(context.Context, string, ...interface{}):
 	case func(context.Context, ...interface{}):
 	case func(string, ...interface{}):
 	case func(...interface{}):
 	case nil:
 	default:
-		panic(fmt.Errorf("invalid logger function type '%T' (type should be in ['func(context.Context, string, ...interface {})','func(context.Context, ...interface {})','func(string, ...interface {})','func(...interface {})'])", logFn))
+		panic(fmt.Errorf("invalid logger function type '%T' (type should be in ['func(context.Context, string, ...interface {})','func(context.Context, ...interface {})','func(string, ...interface {})','func(...interface {})','func(context.Context, configuring.Severity, string, ...interface {})','func(configuring.Severity, string, ...interface {})'])", logFn))
 	}
 	c.logFn = logFn
 	return c
@@ -87,6 +115,15 @@ func (c Configurator) WithContext(ctx context.Context) Configurator {
 	return c
 }
 
+// WithParallelism provides number of worker goroutines validating elements with element validators and element configurator.
+// Validation is sequential if parallelism is lower than 2. Validators should be safe for concurrent use.
+// Error of element with the lowest index or key is returned as for sequential validation.
+// Validation is stopped if context of configurator is done.
+func (c Configurator) WithParallelism(parallelism int) Configurator {
+	c.parallelism = parallelism
+	return c
+}
+
 // WithName provides name of configuration. Helps to find issues in logs.
 // It may be common configuration name or specific for configurable value.
 func (c Configurator) WithName(name string) Configurator {
@@ -130,6 +167,17 @@ func (c Configurator) WithLengthValidators(validators ...Validator) Configurator
 	return c
 }
 
+// WithKeyValidators provides validators of map keys.
+// Configurator is a validator too, so key min, max and allowed values are provided with configurator:
+// configuring.NewConfigurator().WithKeyValidators(configuring.NewConfigurator().WithAllowed("a", "b"))
+func (c Configurator) WithKeyValidators(validators ...Validator) Configurator {
+	var keyValidators []Validator
+	keyValidators = append(keyValidators, c.keyValidators...)
+	keyValidators = append(keyValidators, validators...)
+	c.keyValidators = keyValidators
+	return c
+}
+
 func (c Configurator) WithElementValidators(validators ...Validator) Configurator {
 	var elementValidators []Validator
 	elementValidators = append(elementValidators, c.elementValidators...)
@@ -138,8 +186,35 @@ func (c Configurator) WithElementValidators(validators ...Validator) Configurato
 	return c
 }
 
+// WithElementConfigurator provides configurator of elements of slices, arrays and map values.
+// Every element is configured as a target: invalid elements are replaced with default and written back.
+// Element configurator is named with index or key of element (e.g. 'Hosts[3]') and inherits logger, context and secret of c.
+// Nested collections are configured with nested element configurators (e.g. 'Hosts[3][1]').
+func (c Configurator) WithElementConfigurator(elementConfigurator Configurator) Configurator {
+	c.elementConfigurator = &elementConfigurator
+	return c
+}
+
 func (c Configurator) WithDefault(defaultValue interface{}) Configurator {
 	c.defaultValue = defaultValue
+	c.defaultFn = nil
+	return c
+}
+
+// WithDefaultFunc provides function computing default value with context of configurator.
+// Function is called only if target is invalid and computed value is converted and validated as default value.
+// It replaces default value provided with WithDefault.
+func (c Configurator) WithDefaultFunc(defaultFn func(ctx context.Context) (interface{}, error)) Configurator {
+	c.defaultValue = nil
+	c.defaultFn = defaultFn
+	return c
+}
+
+// WithFallbacks provides candidate values tried in order if target is invalid (e.g. legacy setting, then environment-specific default).
+// The first valid candidate is configured, default value is used if all candidates are invalid.
+// Candidates are converted, normalized and validated like target.
+func (c Configurator) WithFallbacks(values ...interface{}) Configurator {
+	c.fallbackValues = values
 	return c
 }
 
@@ -148,8 +223,8 @@ func (c Configurator) WithCurrent(currentValue interface{}) Configurator {
 	return c
 }
 
-func (c Configurator) log(inputValue interface{}, outputValue interface{}) {
-	if c.logFn == nil {
+func (c Configurator) log(inputValue interface{}, normalizedValue interface{}, outputValue interface{}, choice selection) {
+	if c.logFn == nil || c.isDryRun {
 		return
 	}
 	logValueFormat := "'%v'"
@@ -159,9 +234,9 @@ func (c Configurator) log(inputValue interface{}, outputValue interface{}) {
 	var builder strings.Builder
 	var args []interface{}
 	_, _ = builder.WriteString("configuration")
-	if len(c.name) != 0 {
+	if name := c.qualifiedName(); len(name) != 0 {
 		_, _ = builder.WriteString(" of '%v'")
-		args = append(args, c.name)
+		args = append(args, name)
 	}
 	_, _ = builder.WriteString(":")
 	if c.minValue != nil {
@@ -182,6 +257,12 @@ func (c Configurator) log(inputValue interface{}, outputValue interface{}) {
 		_, _ = builder.WriteString(fmt.Sprintf(" disallowed: [%v%v]", logValueFormat, strings.Repeat(","+logValueFormat, len(c.disallowedValues)-1)))
 		args = append(args, c.disallowedValues...)
 	}
+	if len(c.fallbackValues) > 0 && c.isSecret {
+		_, _ = builder.WriteString(fmt.Sprintf(" fallbacks: [*secret*%v]", strings.Repeat(",*secret*", len(c.fallbackValues)-1)))
+	} else if len(c.fallbackValues) > 0 {
+		_, _ = builder.WriteString(fmt.Sprintf(" fallbacks: [%v%v]", logValueFormat, strings.Repeat(","+logValueFormat, len(c.fallbackValues)-1)))
+		args = append(args, c.fallbackValues...)
+	}
 	if c.defaultValue != nil {
 		_, _ = builder.WriteString(" default: ")
 		_, _ = builder.WriteString(logValueFormat)
@@ -190,26 +271,55 @@ func (c Configurator) log(inputValue interface{}, outputValue interface{}) {
 	if c.isSecret {
 		logValueFormat = "*secret*"
 	}
-	_, _ = builder.WriteString(" input: ")
-	_, _ = builder.WriteString(logValueFormat)
-	_, _ = builder.WriteString(" output: ")
-	_, _ = builder.WriteString(logValueFormat)
-	if !c.isSecret {
-		args = append(args, inputValue, outputValue)
+	writeValue := func(title string, value interface{}) {
+		_, _ = builder.WriteString(title)
+		if c.isOptional && isNil(value) {
+			_, _ = builder.WriteString(unsetValue)
+			return
+		}
+		_, _ = builder.WriteString(logValueFormat)
+		if !c.isSecret {
+			args = append(args, value)
+		}
+	}
+	writeValue(" input: ", inputValue)
+	if len(c.normalizers) > 0 {
+		writeValue(" normalized: ", normalizedValue)
+	}
+	writeValue(" output: ", outputValue)
+	if len(c.fallbackValues) > 0 {
+		_, _ = builder.WriteString(" selected: %v")
+		args = append(args, choice.candidate)
+		if len(choice.rejections) > 0 {
+			_, _ = builder.WriteString(" rejected: [%v]")
+			args = append(args, strings.Join(choice.rejections, "; "))
+		}
+	}
+	for i := range args {
+		args[i] = displayValue(args[i])
 	}
+	c.print(SeverityInfo, builder.String(), args...)
+}
+
+// print prints message with logger of configurator.
+func (c Configurator) print(severity Severity, format string, args ...interface{}) {
 	ctx := c.ctx
 	if ctx == nil {
 		ctx = context.Background()
 	}
 	switch logFn := c.logFn.(type) {
+	case func(context.Context, Severity, string, ...interface{}):
+		logFn(ctx, severity, format, args...)
+	case func(Severity, string, ...interface{}):
+		logFn(severity, format, args...)
 	case func(context.Context, string, ...interface{}):
-		logFn(ctx, builder.String(), args...)
+		logFn(ctx, format, args...)
 	case func(context.Context, ...interface{}):
-		logFn(ctx, fmt.Sprintf(builder.String(), args...))
+		logFn(ctx, fmt.Sprintf(format, args...))
 	case func(string, ...interface{}):
-		logFn(builder.String(), args...)
+		logFn(format, args...)
 	case func(...interface{}):
-		logFn(fmt.Sprintf(builder.String(), args...))
+		logFn(fmt.Sprintf(format, args...))
 	}
 }
 
@@ -217,145 +327,604 @@ func (c Configurator) wrapError(actionName string, err error) error {
 	if err == nil {
 		return nil
 	}
-	if c.name == "" {
-		return fmt.Errorf("%v error: %v", actionName, err)
+	name := c.qualifiedName()
+	if name == "" {
+		return c.errorf(actionName, MessageData{Error: err.Error()}, "%v error: %v", actionName, err)
 	}
-	return fmt.Errorf("%v of '%v' error: %v", actionName, c.name, err)
+	return c.errorf(actionName, MessageData{Error: err.Error()}, "%v of '%v' error: %v", actionName, name, err)
 }
 
 func (c Configurator) convert(target interface{}) (Configurator, error) {
 	var err error
 	if c.currentValue, err = convertNotNil(target, c.currentValue); err != nil {
-		return c, fmt.Errorf("invalid current value: %v", err)
+		return c, c.errorf(MessageInvalidCurrent, MessageData{Error: err.Error()}, "invalid current value: %v", err)
 	}
 	if c.defaultValue, err = convertNotNil(target, c.defaultValue); err != nil {
-		return c, fmt.Errorf("invalid default value: %v", err)
+		return c, c.errorf(MessageInvalidDefault, MessageData{Error: err.Error()}, "invalid default value: %v", err)
+	}
+	if c.fallbackValues, err = convertArray(target, c.fallbackValues); err != nil {
+		return c, c.errorf(MessageInvalidFallbacks, MessageData{Error: err.Error()}, "invalid fallback values: %v", err)
 	}
 	if c.minValue, err = convertNotNil(target, c.minValue); err != nil {
-		return c, fmt.Errorf("invalid min value: %v", err)
+		return c, c.errorf(MessageInvalidMin, MessageData{Error: err.Error()}, "invalid min value: %v", err)
 	}
 	if c.maxValue, err = convertNotNil(target, c.maxValue); err != nil {
-		return c, fmt.Errorf("invalid max value: %v", err)
+		return c, c.errorf(MessageInvalidMax, MessageData{Error: err.Error()}, "invalid max value: %v", err)
 	}
 	if c.allowedValues, err = convertArray(target, c.allowedValues); err != nil {
-		return c, fmt.Errorf("invalid allowed values: %v", err)
+		return c, c.errorf(MessageInvalidAllowed, MessageData{Error: err.Error()}, "invalid allowed values: %v", err)
 	}
 	if c.disallowedValues, err = convertArray(target, c.disallowedValues); err != nil {
-		return c, fmt.Errorf("invalid disallowed values: %v", err)
+		return c, c.errorf(MessageInvalidDisallowed, MessageData{Error: err.Error()}, "invalid disallowed values: %v", err)
 	}
 	if len(c.lengthValidators) > 0 {
 		if err := beWithLength(target); err != nil {
-			return c, fmt.Errorf("unexpected length validators: %v", err)
+			return c, c.errorf(MessageUnexpectedLength, MessageData{Error: err.Error()}, "unexpected length validators: %v", err)
+		}
+	}
+	if len(c.keyValidators) > 0 {
+		if err := beMap(target); err != nil {
+			return c, c.errorf(MessageUnexpectedKeys, MessageData{Error: err.Error()}, "unexpected key validators: %v", err)
 		}
 	}
 	if len(c.elementValidators) > 0 {
 		if err := beEnumerable(target); err != nil {
-			return c, fmt.Errorf("unexpected element validators: %v", err)
+			return c, c.errorf(MessageUnexpectedElements, MessageData{Error: err.Error()}, "unexpected element validators: %v", err)
+		}
+	}
+	if c.elementConfigurator != nil {
+		if err := beCollection(target); err != nil {
+			return c, c.errorf(MessageUnexpectedConfigurator, MessageData{Error: err.Error()}, "unexpected element configurator: %v", err)
 		}
 	}
 	return c, nil
 }
 
 func (c Configurator) validate(target interface{}) error {
+	if err := c.validateMin(target); err != nil {
+		return err
+	}
+	if err := c.validateMax(target); err != nil {
+		return err
+	}
+	if err := c.validateAllowed(target); err != nil {
+		return err
+	}
+	if err := c.validateDisallowed(target); err != nil {
+		return err
+	}
+	if err := c.validateTarget(target); err != nil {
+		return err
+	}
+	if err := c.validateLength(target); err != nil {
+		return err
+	}
+	if err := c.validateKeys(target); err != nil {
+		return err
+	}
+	if err := c.validateElements(target); err != nil {
+		return err
+	}
+	return c.validateElementsConfiguration(target)
+}
+
+// evaluate validates target with every rule of c like validate, adds evaluations of rules to report
+// and returns error of the first failed rule.
+func (c Configurator) evaluate(target interface{}, report *Report) error {
+	var firstErr error
+	if c.minValue != nil {
+		err := c.validateMin(target)
+		report.addRule("min", c.formatValue(c.minValue), err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if c.maxValue != nil {
+		err := c.validateMax(target)
+		report.addRule("max", c.formatValue(c.maxValue), err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if len(c.allowedValues) > 0 {
+		err := c.validateAllowed(target)
+		report.addRule("allowed", c.formatValues(c.allowedValues), err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if len(c.disallowedValues) > 0 {
+		err := c.validateDisallowed(target)
+		report.addRule("disallowed", c.formatValues(c.disallowedValues), err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if len(c.targetValidators) > 0 {
+		err := c.validateTarget(target)
+		report.addRule("validators", "", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if len(c.lengthValidators) > 0 {
+		err := c.validateLength(target)
+		report.addRule("length validators", "", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if len(c.keyValidators) > 0 {
+		err := c.validateKeys(target)
+		report.addRule("key validators", "", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if len(c.elementValidators) > 0 {
+		err := c.validateElements(target)
+		report.addRule("element validators", "", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if c.elementConfigurator != nil {
+		err := c.validateElementsConfiguration(target)
+		report.addRule("element configurator", "", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (c Configurator) validateMin(target interface{}) error {
 	if c.minValue != nil {
-		comparisonResult, err := compare(target, c.minValue)
+		if isNil(target) {
+			return c.errorf(MessageNotNil, MessageData{}, "argument should not be nil")
+		}
+		comparisonResult, err := c.compare(target, c.minValue)
 		if err != nil {
-			return fmt.Errorf("invalid min value: %v", err)
+			return c.errorf(MessageInvalidMin, MessageData{Error: err.Error()}, "invalid min value: %v", err)
 		}
 		if comparisonResult == -1 {
-			return fmt.Errorf("argument should be greater than or equal to '%v'", c.minValue)
+			return c.errorf(MessageMin, MessageData{Value: target, Bound: c.minValue}, "argument should be greater than or equal to '%v'", c.minValue)
 		}
 	}
+	return nil
+}
+
+func (c Configurator) validateMax(target interface{}) error {
 	if c.maxValue != nil {
-		comparisonResult, err := compare(target, c.maxValue)
+		if isNil(target) {
+			return c.errorf(MessageNotNil, MessageData{}, "argument should not be nil")
+		}
+		comparisonResult, err := c.compare(target, c.maxValue)
 		if err != nil {
-			return fmt.Errorf("invalid max value: %v", err)
+			return c.errorf(MessageInvalidMax, MessageData{Error: err.Error()}, "invalid max value: %v", err)
 		}
 		if comparisonResult == 1 {
-			return fmt.Errorf("argument should be lower than or equal to '%v'", c.maxValue)
+			return c.errorf(MessageMax, MessageData{Value: target, Bound: c.maxValue}, "argument should be lower than or equal to '%v'", c.maxValue)
 		}
 	}
+	return nil
+}
+
+func (c Configurator) validateAllowed(target interface{}) error {
 	if len(c.allowedValues) > 0 {
-		if !hasEqual(target, c.allowedValues) {
-			return fmt.Errorf(fmt.Sprintf("argument should be in allowed values [%v%v]", "'%v'", strings.Repeat(",'%v'", len(c.allowedValues)-1)), c.allowedValues...)
+		if !c.hasEqual(target, c.allowedValues) {
+			bound := formatList(c.allowedValues)
+			return c.errorf(MessageAllowed, MessageData{Value: target, Bound: bound}, "argument should be in allowed values [%v]", bound)
 		}
 	}
+	return nil
+}
+
+func (c Configurator) validateDisallowed(target interface{}) error {
 	if len(c.disallowedValues) > 0 {
-		if hasEqual(target, c.disallowedValues) {
-			return fmt.Errorf(fmt.Sprintf("argument should not be in disallowed values [%v%v]", "'%v'", strings.Repeat(",'%v'", len(c.disallowedValues)-1)), c.disallowedValues...)
+		if c.hasEqual(target, c.disallowedValues) {
+			bound := formatList(c.disallowedValues)
+			return c.errorf(MessageDisallowed, MessageData{Value: target, Bound: bound}, "argument should not be in disallowed values [%v]", bound)
 		}
 	}
+	return nil
+}
+
+func (c Configurator) validateTarget(target interface{}) error {
 	for i, validator := range c.targetValidators {
-		if err := validator.Validate(target); err != nil {
-			return fmt.Errorf("argument should be validated with validator at index '%v': %v", i, err)
+		if err := c.validateWith(validator, target); err != nil {
+			return c.errorf(MessageValidator, MessageData{Value: target, Validator: i, Error: err.Error()}, "argument should be validated with validator at index '%v': %v", i, err)
 		}
 	}
+	return nil
+}
+
+func (c Configurator) validateLength(target interface{}) error {
 	if len(c.lengthValidators) > 0 {
 		length := getLength(target)
 		for i, validator := range c.lengthValidators {
-			if err := validator.Validate(length); err != nil {
-				return fmt.Errorf("argument length should be validated with validator at index '%v': %v", i, err)
+			if err := c.validateWith(validator, length); err != nil {
+				return c.errorf(MessageLengthValidator, MessageData{Value: length, Validator: i, Error: err.Error()}, "argument length should be validated with validator at index '%v': %v", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c Configurator) validateKeys(target interface{}) error {
+	if len(c.keyValidators) > 0 {
+		err := c.validateEachElementSequentially(target, func(index int, rKey reflect.Value, element interface{}) error {
+			key := rKey.Interface()
+			for i, validator := range c.keyValidators {
+				if err := c.validateWith(validator, key); err != nil {
+					return elementError{index: index, rKey: rKey, element: key, validator: i, err: err}
+				}
 			}
+			return nil
+		})
+		if err, ok := err.(elementError); ok {
+			return c.errorf(MessageKeyValidator, MessageData{Value: err.element, Index: err.element, Validator: err.validator, Error: err.Error()}, "argument key '%v' should be validated with validator at index '%v': %v", err.element, err.validator, err.err)
 		}
+		return err
 	}
+	return nil
+}
+
+func (c Configurator) validateElements(target interface{}) error {
 	if len(c.elementValidators) > 0 {
-		elements := getElements(target)
-		for i, element := range elements {
-			for j, validator := range c.elementValidators {
-				if err := validator.Validate(element); err != nil {
-					return fmt.Errorf("argument element at index '%v' should be validated with validator at index '%v': %v", i, j, err)
+		ctx, validators := c.validatorContext(), c.elementValidators
+		err := c.validateEachElement(target, func(index int, rKey reflect.Value, element interface{}) error {
+			for i, validator := range validators {
+				if err := validateWithContext(ctx, validator, element); err != nil {
+					return elementError{index: index, rKey: rKey, element: element, validator: i, err: err}
 				}
 			}
+			return nil
+		})
+		if err, ok := err.(elementError); ok {
+			data := MessageData{Value: err.element, Index: err.index, Validator: err.validator, Error: err.Error()}
+			if err.rKey.IsValid() {
+				data.Index = err.rKey.Interface()
+				return c.errorf(MessageMapElementValidator, data, "argument element with key '%v' should be validated with validator at index '%v': %v", data.Index, err.validator, err.err)
+			}
+			return c.errorf(MessageElementValidator, data, "argument element at index '%v' should be validated with validator at index '%v': %v", err.index, err.validator, err.err)
 		}
+		return err
 	}
 	return nil
 }
 
+func (c Configurator) validateElementsConfiguration(target interface{}) error {
+	if c.elementConfigurator != nil {
+		ctx, elementConfigurator := c.validatorContext(), c.elementConfigurator.inherit(c)
+		err := c.validateEachElement(target, func(index int, rKey reflect.Value, element interface{}) error {
+			if err := validateWithContext(ctx, elementConfigurator, element); err != nil {
+				return elementError{index: index, rKey: rKey, element: element, err: err}
+			}
+			return nil
+		})
+		if err, ok := err.(elementError); ok {
+			data := MessageData{Value: err.element, Index: err.index, Error: err.Error()}
+			if err.rKey.IsValid() {
+				data.Index = err.rKey.Interface()
+				return c.errorf(MessageMapElementConfigurator, data, "argument element with key '%v' should be validated with element configurator: %v", data.Index, err.err)
+			}
+			return c.errorf(MessageElementConfigurator, data, "argument element at index '%v' should be validated with element configurator: %v", err.index, err.err)
+		}
+		return err
+	}
+	return nil
+}
+
+// elementError is error of element validation formatted by configurator.
+type elementError struct {
+	index     int
+	rKey      reflect.Value
+	element   interface{}
+	validator int
+	err       error
+}
+
+func (e elementError) Error() string {
+	return e.err.Error()
+}
+
 func (c Configurator) Validate(target interface{}) error {
 	var err error
+	if target == nil {
+		return c.wrapError("validation", c.errorf(MessageNotNil, MessageData{}, "argument should not be nil"))
+	}
+	if optionalTarget, ok := target.(optional); ok {
+		return c.validateOptional(optionalTarget)
+	}
 	if target, err = convert(target, target); err != nil {
 		return c.wrapError("validation", err)
 	}
 	if c, err = c.convert(target); err != nil {
 		return c.wrapError("validation", err)
 	}
-	return c.wrapError("validation", c.validate(target))
+	if target, err = c.normalize(target); err != nil {
+		return c.wrapError("validation", err)
+	}
+	return c.wrapValidationError(c.validate(target))
+}
+
+// ValidateContext validates target like Validate with provided context.
+func (c Configurator) ValidateContext(ctx context.Context, target interface{}) error {
+	return c.WithContext(ctx).Validate(target)
+}
+
+// selection describes candidate selected by configuration and reasons of rejection of previous candidates.
+type selection struct {
+	candidate  string
+	rejections []string
+}
+
+// configure returns normalized target, configured result and selection of candidate.
+// If report is not nil, validation of default value and evaluations of rules of input are added to report.
+func (c Configurator) configure(target interface{}, report *Report) (interface{}, interface{}, selection, error) {
+	var choice selection
+	var defaultErr error
+	if c.defaultValue != nil {
+		defaultErr = c.validate(c.defaultValue)
+		if report != nil {
+			report.Default = c.formatValue(c.defaultValue)
+			report.DefaultValidated = true
+			if defaultErr != nil {
+				report.DefaultError = defaultErr.Error()
+			}
+		} else if defaultErr != nil {
+			return nil, nil, choice, c.errorf(MessageDefaultValue, MessageData{Value: c.defaultValue, Error: defaultErr.Error()}, "default value error: %v", defaultErr)
+		}
+	}
+	normalized, result, err := c.configureCandidate(target, report)
+	if defaultErr != nil {
+		return normalized, nil, choice, c.errorf(MessageDefaultValue, MessageData{Value: c.defaultValue, Error: defaultErr.Error()}, "default value error: %v", defaultErr)
+	}
+	if err == nil {
+		choice.candidate = "input"
+		return normalized, result, choice, nil
+	}
+	if len(c.fallbackValues) > 0 {
+		choice.rejections = append(choice.rejections, fmt.Sprintf("input: %v", err))
+	}
+	for i, fallbackValue := range c.fallbackValues {
+		if ctxErr := c.contextErr(); ctxErr != nil {
+			return normalized, nil, choice, ctxErr
+		}
+		_, fallbackResult, fallbackErr := c.configureCandidate(fallbackValue, nil)
+		if fallbackErr == nil {
+			choice.candidate = fmt.Sprintf("fallback at index '%v'", i)
+			return normalized, fallbackResult, choice, nil
+		}
+		choice.rejections = append(choice.rejections, fmt.Sprintf("fallback at index '%v': %v", i, fallbackErr))
+	}
+	invalid := normalized
+	if invalid == nil {
+		invalid = target
+	}
+	result, err = c.fallback(invalid, err)
+	if err == nil {
+		choice.candidate = "default"
+	}
+	return normalized, result, choice, err
 }
 
-func (c Configurator) configure(target interface{}) (interface{}, error) {
+// configureCandidate normalizes, configures elements and validates candidate value.
+// It returns nil normalized value if candidate could not be normalized.
+// If report is not nil, evaluations of rules are added to report.
+func (c Configurator) configureCandidate(target interface{}, report *Report) (interface{}, interface{}, error) {
 	var err error
+	if len(c.normalizers) > 0 {
+		target, err = c.normalize(target)
+		report.addRule("normalization", "", err)
+		if err != nil {
+			return nil, nil, err
+		}
+		if report != nil {
+			report.Normalized = c.formatSecretValue(target)
+		}
+	}
+	normalized := target
+	if err = c.validateSet(target); err != nil {
+		report.addRule("not nil", "", err)
+		return normalized, nil, err
+	}
+	if c.isOptional && isNil(target) {
+		return normalized, target, nil
+	}
+	if c.elementConfigurator != nil {
+		target, err = c.configureElements(target)
+		report.addRule("element configuration", "", err)
+		if err != nil {
+			return normalized, nil, err
+		}
+	}
+	if report != nil {
+		err = c.evaluate(target, report)
+	} else {
+		err = c.validate(target)
+	}
+	if err != nil {
+		return normalized, nil, err
+	}
+	return normalized, target, nil
+}
+
+// validateSet rejects nil pointer and nil interface targets if they can be replaced with fallback or default values
+// unless nil is allowed explicitly.
+func (c Configurator) validateSet(target interface{}) error {
+	if !isNil(target) || c.defaultValue == nil && c.defaultFn == nil && len(c.fallbackValues) == 0 {
+		return nil
+	}
+	for _, allowedValue := range c.allowedValues {
+		if isNil(allowedValue) {
+			return nil
+		}
+	}
+	return c.errorf(MessageNotNil, MessageData{}, "argument should not be nil")
+}
+
+// fallback returns default value instead of invalid target.
+func (c Configurator) fallback(target interface{}, err error) (interface{}, error) {
+	if ctxErr := c.contextErr(); ctxErr != nil {
+		return nil, ctxErr
+	}
 	if c.defaultValue != nil {
-		if err = c.validate(c.defaultValue); err != nil {
-			return nil, fmt.Errorf("default value error: %v", err)
+		return c.defaultValue, nil
+	}
+	if c.defaultFn != nil {
+		return c.computeDefault(target)
+	}
+	return nil, c.errorf(MessageTargetValue, MessageData{Value: target, Error: err.Error()}, "target value error: %v", err)
+}
+
+// computeDefault computes, converts and validates default value of target type.
+func (c Configurator) computeDefault(target interface{}) (interface{}, error) {
+	defaultValue, err := c.defaultFn(c.getContext())
+	if err == nil {
+		if defaultValue, err = convert(target, defaultValue); err != nil {
+			err = c.errorf(MessageInvalidDefault, MessageData{Error: err.Error()}, "invalid default value: %v", err)
 		}
 	}
-	if err = c.validate(target); err != nil {
-		if c.defaultValue != nil {
-			return c.defaultValue, nil
+	if err == nil {
+		err = c.validate(defaultValue)
+	}
+	if err != nil {
+		if ctxErr := c.contextErr(); ctxErr != nil {
+			return nil, ctxErr
 		}
-		return nil, fmt.Errorf("target value error: %v", err)
+		return nil, c.errorf(MessageDefaultValue, MessageData{Value: defaultValue, Error: err.Error()}, "default value error: %v", err)
 	}
-	return target, nil
+	return defaultValue, nil
 }
 
+// configureElements configures elements of a copy of target collection.
+func (c Configurator) configureElements(target interface{}) (interface{}, error) {
+	rResult := reflect.New(reflect.TypeOf(target)).Elem()
+	rResult.Set(reflect.ValueOf(target))
+	rCollection := rResult
+	for rCollection.Kind() == reflect.Ptr && !rCollection.IsNil() {
+		rCollectionPointer := reflect.New(rCollection.Type().Elem())
+		rCollectionPointer.Elem().Set(rCollection.Elem())
+		rCollection.Set(rCollectionPointer)
+		rCollection = rCollectionPointer.Elem()
+	}
+	switch rCollection.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rCollection.Kind() == reflect.Slice {
+			if rCollection.IsNil() {
+				return target, nil
+			}
+			rSlice := reflect.MakeSlice(rCollection.Type(), rCollection.Len(), rCollection.Len())
+			reflect.Copy(rSlice, rCollection)
+			rCollection.Set(rSlice)
+		}
+		for i := 0; i < rCollection.Len(); i++ {
+			if err := c.elementConfigurator.inherit(c).WithName(fmt.Sprintf("%v[%v]", c.qualifiedName(), i)).Configure(rCollection.Index(i).Addr().Interface()); err != nil {
+				return nil, err
+			}
+		}
+	case reflect.Map:
+		if rCollection.IsNil() {
+			return target, nil
+		}
+		rMap := reflect.MakeMap(rCollection.Type())
+		for _, rKey := range c.sortedKeys(rCollection) {
+			rElementPointer := reflect.New(rCollection.Type().Elem())
+			rElementPointer.Elem().Set(rCollection.MapIndex(rKey))
+			if err := c.elementConfigurator.inherit(c).WithName(fmt.Sprintf("%v[%v]", c.qualifiedName(), rKey.Interface())).Configure(rElementPointer.Interface()); err != nil {
+				return nil, err
+			}
+			rMap.SetMapIndex(rKey, rElementPointer.Elem())
+		}
+		rCollection.Set(rMap)
+	}
+	return rResult.Interface(), nil
+}
+
+// inherit provides logger, context, secret, dry run, messages and comparators of parent configurator if not set.
+func (c Configurator) inherit(parent Configurator) Configurator {
+	if c.ctx == nil {
+		c.ctx = parent.ctx
+	}
+	if c.logFn == nil {
+		c.logFn = parent.logFn
+		c.logChangesOnly = parent.logChangesOnly
+	}
+	if c.logValueFormat == "" {
+		c.logValueFormat = parent.logValueFormat
+	}
+	c.isSecret = c.isSecret || parent.isSecret
+	c.isDryRun = c.isDryRun || parent.isDryRun
+	if c.locale == "" {
+		c.locale = parent.locale
+	}
+	if c.messages == nil {
+		c.messages = parent.messages
+	}
+	c.compareFns = append(c.compareFns[:len(c.compareFns):len(c.compareFns)], parent.compareFns...)
+	c.equalFns = append(c.equalFns[:len(c.equalFns):len(c.equalFns)], parent.equalFns...)
+	return c
+}
+
+// Configure validates value targetPointer points to and sets default value if it is invalid.
+// Nil pointer targets are replaced with fallback or default values allocated by configurator unless nil is allowed,
+// and interface targets are configured by types of their values.
 func (c Configurator) Configure(targetPointer interface{}) error {
+	return c.configureWithReport(targetPointer, nil)
+}
+
+// configureWithReport configures target and fills report if it is not nil.
+func (c Configurator) configureWithReport(targetPointer interface{}, report *Report) error {
 	var err error
 	if err = beConfigurable(targetPointer); err != nil {
-		return c.wrapError("configuration", fmt.Errorf("target value is not configurable: %v", err))
+		return c.wrapError("configuration", c.errorf(MessageNotConfigurable, MessageData{Error: err.Error()}, "target value is not configurable: %v", err))
+	}
+	if optionalPointer, ok := targetPointer.(optionalSetter); ok {
+		return c.configureOptional(optionalPointer, report)
 	}
 	target := getValue(targetPointer)
 	if c, err = c.convert(target); err != nil {
 		return c.wrapError("configuration", err)
 	}
+	return c.configurePointer(targetPointer, target, report)
+}
+
+// configurePointer configures target of converted configurator and sets result by target pointer.
+// It fills input, output and warnings of report if it is not nil.
+func (c Configurator) configurePointer(targetPointer interface{}, target interface{}, report *Report) error {
+	if err := c.contextErr(); err != nil {
+		return err
+	}
+	if report != nil {
+		report.Input = c.formatSecretValue(target)
+	}
 	if c.currentValue != nil {
 		target = c.currentValue
+		if report != nil {
+			report.Current = c.formatSecretValue(target)
+		}
 	}
-	result, err := c.configure(target)
+	normalized, result, choice, err := c.configure(target, nil)
 	if err != nil {
+		if ctxErr := c.contextErr(); ctxErr != nil {
+			return ctxErr
+		}
 		return c.wrapError("configuration", err)
 	}
-	if !c.logChangesOnly || !equal(target, result) {
-		c.log(target, result)
+	if !c.logChangesOnly || !c.equal(target, result) {
+		c.log(target, normalized, result, choice)
+	}
+	if len(c.advisories) > 0 {
+		warnings := c.advise(result)
+		c.warn(warnings)
+		if report != nil {
+			report.Warnings = warnings
+		}
+	}
+	if report != nil {
+		report.Output = c.formatSecretValue(result)
 	}
 	setValue(targetPointer, result)
 	return nil
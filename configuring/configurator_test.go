@@ -3,7 +3,9 @@ package configuring
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestConfigurator_WithLogger(t *testing.T) {
@@ -42,8 +44,8 @@ func TestConfigurator_WithLogger(t *testing.T) {
 		defer func() {
 			rerr := recover()
 			if rerr != nil {
-				if err, ok := rerr.(error); !ok || err == nil || err.Error() != "invalid logger function type 'func()' (type should be in ['func(context.Context, string, ...interface {})','func(context.Context, ...interface {})','func(string, ...interface {})','func(...interface {})'])" {
-					t.Errorf("expected '%v', was '%v'", "invalid logger function type 'func()' (type should be in ['func(context.Context, string, ...interface {})','func(context.Context, ...interface {})','func(string, ...interface {})','func(...interface {})'])", rerr)
+				if err, ok := rerr.(error); !ok || err == nil || err.Error() != "invalid logger function type 'func()' (type should be in ['func(context.Context, string, ...interface {})','func(context.Context, ...interface {})','func(string, ...interface {})','func(...interface {})','func(context.Context, configuring.Severity, string, ...interface {})','func(configuring.Severity, string, ...interface {})'])" {
+					t.Errorf("expected '%v', was '%v'", "invalid logger function type 'func()' (type should be in ['func(context.Context, string, ...interface {})','func(context.Context, ...interface {})','func(string, ...interface {})','func(...interface {})','func(context.Context, configuring.Severity, string, ...interface {})','func(configuring.Severity, string, ...interface {})'])", rerr)
 				}
 				calls = calls + 1
 			}
@@ -374,6 +376,94 @@ func TestConfigurator_WithDefault(t *testing.T) {
 	}
 }
 
+func TestConfigurator_WithDefaultFunc(t *testing.T) {
+	calls := 0
+	workers := func(ctx context.Context) (interface{}, error) {
+		calls = calls + 1
+		return ctx.Value("cpus").(int) * 2, nil
+	}
+	ctx := context.WithValue(context.Background(), "cpus", 4)
+	value := int64(3)
+	err := NewConfigurator().WithContext(ctx).WithMin(1).WithDefaultFunc(workers).Configure(&value)
+	if err != nil || value != 3 || calls != 0 {
+		t.Errorf("expected '%v', was '%v' ('%v', '%v')", 3, value, err, calls)
+	}
+	value = 0
+	err = NewConfigurator().WithContext(ctx).WithMin(1).WithDefaultFunc(workers).Configure(&value)
+	if err != nil || value != 8 || calls != 1 {
+		t.Errorf("expected '%v', was '%v' ('%v', '%v')", 8, value, err, calls)
+	}
+	err = NewConfigurator().WithContext(ctx).WithName("Workers").WithMin(1).WithMax(4).WithDefaultFunc(workers).Configure(&value)
+	if err == nil || err.Error() != "configuration of 'Workers' error: default value error: argument should be lower than or equal to '4'" {
+		t.Errorf("expected '%v', was '%v'", "configuration of 'Workers' error: default value error: argument should be lower than or equal to '4'", err)
+	}
+	err = NewConfigurator().WithMin(1).WithDefaultFunc(func(ctx context.Context) (interface{}, error) {
+		return "a", nil
+	}).Configure(new(int))
+	if err == nil || err.Error() != "configuration error: default value error: invalid default value: argument of type 'string' should be convertible to type 'int'" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: default value error: invalid default value: argument of type 'string' should be convertible to type 'int'", err)
+	}
+	err = NewConfigurator().WithMin(1).WithDefaultFunc(func(ctx context.Context) (interface{}, error) {
+		return nil, fmt.Errorf("hostname is unknown")
+	}).Configure(new(int))
+	if err == nil || err.Error() != "configuration error: default value error: hostname is unknown" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: default value error: hostname is unknown", err)
+	}
+	value = 0
+	err = NewConfigurator().WithMin(1).WithDefaultFunc(workers).WithDefault(2).Configure(&value)
+	if err != nil || value != 2 || calls != 2 {
+		t.Errorf("expected '%v', was '%v' ('%v', '%v')", 2, value, err, calls)
+	}
+}
+
+func TestConfigurator_WithFallbacks(t *testing.T) {
+	calls := 0
+	port := 0
+	err := NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		expected := "configuration of 'Port': min: '1' fallbacks: ['0','8443'] default: '8080' input: '0' output: '8443' selected: fallback at index '1' rejected: [input: argument should be greater than or equal to '1'; fallback at index '0': argument should be greater than or equal to '1']"
+		if message := fmt.Sprintf(format, args...); message != expected {
+			t.Errorf("expected '%v', was '%v'", expected, message)
+		}
+		calls = calls + 1
+	}).WithName("Port").WithMin(1).WithFallbacks(0, int64(8443)).WithDefault(8080).Configure(&port)
+	if err != nil || port != 8443 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 8443, port, err)
+	}
+	port = 80
+	err = NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		expected := "configuration: min: '1' fallbacks: [*secret*] input: *secret* output: *secret* selected: input"
+		if message := fmt.Sprintf(format, args...); message != expected {
+			t.Errorf("expected '%v', was '%v'", expected, message)
+		}
+		calls = calls + 1
+	}).Secret().WithMin(1).WithFallbacks(8443).Configure(&port)
+	if err != nil || port != 80 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 80, port, err)
+	}
+	port = 0
+	err = NewConfigurator().WithMin(1).WithFallbacks(0, -1).WithDefault(8080).Configure(&port)
+	if err != nil || port != 8080 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 8080, port, err)
+	}
+	port = 0
+	err = NewConfigurator().WithMin(1).WithFallbacks(0).Configure(&port)
+	if err == nil || err.Error() != "configuration error: target value error: argument should be greater than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: target value error: argument should be greater than or equal to '1'", err)
+	}
+	err = NewConfigurator().WithMin(1).WithFallbacks(1, "a").Configure(&port)
+	if err == nil || err.Error() != "configuration error: invalid fallback values: invalid element at index '1': argument of type 'string' should be convertible to type 'int'" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: invalid fallback values: invalid element at index '1': argument of type 'string' should be convertible to type 'int'", err)
+	}
+	name := " "
+	err = NewConfigurator().WithNormalizers(TrimSpace).WithDisallowed("").WithFallbacks(" legacy ").Configure(&name)
+	if err != nil || name != "legacy" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "legacy", name, err)
+	}
+	if calls != 2 {
+		t.Errorf("expected '%v', was '%v'", 2, calls)
+	}
+}
+
 func TestConfigurator_WithCurrent(t *testing.T) {
 	calls := 0
 	err := NewConfigurator().WithLogger(func(format string, args ...interface{}) {
@@ -481,3 +571,279 @@ func TestConfigurator_Configure(t *testing.T) {
 		t.Errorf("expected '%v', was '%v'", error(nil), err)
 	}
 }
+
+func TestConfigurator_WithKeyValidators(t *testing.T) {
+	calls := 0
+	err := NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		if message := fmt.Sprintf(format, args...); message != "configuration: input: 'map[a:1 b:2]' output: 'map[a:1 b:2]'" {
+			t.Errorf("expected '%v', was '%v'", "configuration: input: 'map[a:1 b:2]' output: 'map[a:1 b:2]'", message)
+		}
+		calls = calls + 1
+	}).WithKeyValidators(NewConfigurator().WithAllowed("a", "b")).Configure(&map[string]int{"a": 1, "b": 2})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		calls = calls - 1
+	}).WithKeyValidators(NewConfigurator().WithMax("b")).Configure(&map[string]int{"d": 1, "a": 2, "c": 3})
+	if err == nil || err.Error() != "configuration error: target value error: argument key 'c' should be validated with validator at index '0': validation error: argument should be lower than or equal to 'b'" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: target value error: argument key 'c' should be validated with validator at index '0': validation error: argument should be lower than or equal to 'b'", err)
+	}
+	err = NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		calls = calls - 1
+	}).WithKeyValidators(NewConfigurator().WithMax("b")).Configure(new([]string))
+	if err == nil || err.Error() != "configuration error: unexpected key validators: argument of type '[]string' should be a map" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: unexpected key validators: argument of type '[]string' should be a map", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected '%v', was '%v'", 1, calls)
+	}
+}
+
+func TestConfigurator_WithElementValidators(t *testing.T) {
+	err := NewConfigurator().WithElementValidators(NewConfigurator().WithMin(1)).Validate([]int{1, 0, 0})
+	if err == nil || err.Error() != "validation error: argument element at index '1' should be validated with validator at index '0': validation error: argument should be greater than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument element at index '1' should be validated with validator at index '0': validation error: argument should be greater than or equal to '1'", err)
+	}
+	for i := 0; i < 10; i++ {
+		err = NewConfigurator().WithElementValidators(NewConfigurator().WithMin(1)).Validate(map[int]int{3: 0, 2: 0, 10: 0, 1: 1})
+		if err == nil || err.Error() != "validation error: argument element with key '2' should be validated with validator at index '0': validation error: argument should be greater than or equal to '1'" {
+			t.Errorf("expected '%v', was '%v'", "validation error: argument element with key '2' should be validated with validator at index '0': validation error: argument should be greater than or equal to '1'", err)
+		}
+	}
+	err = NewConfigurator().WithElementValidators(NewConfigurator().WithDisallowed(' ')).Validate("añ b")
+	if err == nil || err.Error() != "validation error: argument element at index '3' should be validated with validator at index '0': validation error: argument should not be in disallowed values ['32']" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument element at index '3' should be validated with validator at index '0': validation error: argument should not be in disallowed values ['32']", err)
+	}
+}
+
+func newBenchmarkSliceElements() []int {
+	return make([]int, 100000)
+}
+
+func newBenchmarkMapElements() map[int]int {
+	target := make(map[int]int, 100000)
+	for i := 0; i < 100000; i++ {
+		target[i] = i
+	}
+	return target
+}
+
+func BenchmarkConfigurator_Validate_SliceElements(b *testing.B) {
+	configurator := NewConfigurator().WithElementValidators(NewConfigurator().WithMin(0))
+	target := newBenchmarkSliceElements()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := configurator.Validate(target); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkConfigurator_Validate_MapElements(b *testing.B) {
+	configurator := NewConfigurator().WithElementValidators(NewConfigurator().WithMin(0))
+	target := newBenchmarkMapElements()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := configurator.Validate(target); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPlan_Validate_SliceElements(b *testing.B) {
+	plan, err := NewConfigurator().WithElementValidators(NewConfigurator().WithMin(0)).Compile(reflect.TypeOf([]int(nil)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	target := newBenchmarkSliceElements()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := plan.Validate(target); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPlan_Validate_MapElements(b *testing.B) {
+	plan, err := NewConfigurator().WithElementValidators(NewConfigurator().WithMin(0)).Compile(reflect.TypeOf(map[int]int(nil)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	target := newBenchmarkMapElements()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := plan.Validate(target); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestConfigurator_WithElementConfigurator(t *testing.T) {
+	var messages []string
+	hosts := [][]string{{"a", ""}, {""}}
+	err := NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}).WithName("Hosts").WithElementConfigurator(NewConfigurator().WithElementConfigurator(NewConfigurator().WithDisallowed("").WithDefault("localhost"))).Configure(&hosts)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := fmt.Sprint(hosts); result != "[[a localhost] [localhost]]" {
+		t.Errorf("expected '%v', was '%v'", "[[a localhost] [localhost]]", result)
+	}
+	expected := []string{
+		"configuration of 'Hosts[0][0]': disallowed: [''] default: 'localhost' input: 'a' output: 'a'",
+		"configuration of 'Hosts[0][1]': disallowed: [''] default: 'localhost' input: '' output: 'localhost'",
+		"configuration of 'Hosts[0]': input: '[a ]' output: '[a localhost]'",
+		"configuration of 'Hosts[1][0]': disallowed: [''] default: 'localhost' input: '' output: 'localhost'",
+		"configuration of 'Hosts[1]': input: '[]' output: '[localhost]'",
+		"configuration of 'Hosts': input: '[[a ] []]' output: '[[a localhost] [localhost]]'",
+	}
+	if fmt.Sprintf("%q", messages) != fmt.Sprintf("%q", expected) {
+		t.Errorf("expected '%q', was '%q'", expected, messages)
+	}
+	original := []int{0, 5}
+	values := original
+	err = NewConfigurator().WithElementConfigurator(NewConfigurator().WithMin(1)).Configure(&values)
+	if err == nil || err.Error() != "configuration error: target value error: configuration of '[0]' error: target value error: argument should be greater than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: target value error: configuration of '[0]' error: target value error: argument should be greater than or equal to '1'", err)
+	}
+	err = NewConfigurator().WithElementConfigurator(NewConfigurator().WithMin(1).WithDefault(1)).Configure(&values)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if fmt.Sprint(values) != "[1 5]" || fmt.Sprint(original) != "[0 5]" {
+		t.Errorf("expected '%v', was '%v'", "[1 5] [0 5]", []interface{}{values, original})
+	}
+	timeouts := map[string][2]int{"b": {0, 1}, "a": {2, 0}}
+	err = NewConfigurator().WithName("Timeouts").WithElementConfigurator(NewConfigurator().WithElementConfigurator(NewConfigurator().WithMin(1))).WithDefault(map[string][2]int{}).Configure(&timeouts)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if len(timeouts) != 0 {
+		t.Errorf("expected '%v', was '%v'", map[string][2]int{}, timeouts)
+	}
+	err = NewConfigurator().WithName("Timeouts").WithElementConfigurator(NewConfigurator().WithElementConfigurator(NewConfigurator().WithMin(1))).Validate(map[string][2]int{"b": {0, 1}, "a": {2, 0}})
+	if err == nil || err.Error() != "validation of 'Timeouts' error: argument element with key 'a' should be validated with element configurator: validation error: argument element at index '1' should be validated with element configurator: validation error: argument should be greater than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "validation of 'Timeouts' error: argument element with key 'a' should be validated with element configurator: validation error: argument element at index '1' should be validated with element configurator: validation error: argument should be greater than or equal to '1'", err)
+	}
+	ports := map[string]int{"a": 0, "b": 80}
+	err = NewConfigurator().WithElementConfigurator(NewConfigurator().WithMin(1).WithDefault(8080)).Configure(&ports)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if len(ports) != 2 || ports["a"] != 8080 || ports["b"] != 80 {
+		t.Errorf("expected '%v', was '%v'", map[string]int{"a": 8080, "b": 80}, ports)
+	}
+	err = NewConfigurator().WithElementConfigurator(NewConfigurator()).Configure(new(string))
+	if err == nil || err.Error() != "configuration error: unexpected element configurator: argument of type 'string' should be a slice, an array or a map" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: unexpected element configurator: argument of type 'string' should be a slice, an array or a map", err)
+	}
+}
+
+func TestConfigurator_Configure_LosslessConversion(t *testing.T) {
+	err := NewConfigurator().WithMax(300).Configure(new(uint8))
+	if err == nil || err.Error() != "configuration error: invalid max value: argument '300' of type 'int' overflows type 'uint8'" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: invalid max value: argument '300' of type 'int' overflows type 'uint8'", err)
+	}
+	err = NewConfigurator().WithMin(-1).Configure(new(uint))
+	if err == nil || err.Error() != "configuration error: invalid min value: argument '-1' of type 'int' overflows type 'uint'" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: invalid min value: argument '-1' of type 'int' overflows type 'uint'", err)
+	}
+	err = NewConfigurator().WithAllowed(1, 0.5).Configure(new(int))
+	if err == nil || err.Error() != "configuration error: invalid allowed values: invalid element at index '1': argument '0.5' of type 'float64' loses precision on conversion to type 'int'" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: invalid allowed values: invalid element at index '1': argument '0.5' of type 'float64' loses precision on conversion to type 'int'", err)
+	}
+	err = NewConfigurator().WithDefault(65).Configure(new(string))
+	if err == nil || err.Error() != "configuration error: invalid default value: argument '65' of type 'int' should not be converted to type 'string' (conversion produces a rune instead of digits)" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: invalid default value: argument '65' of type 'int' should not be converted to type 'string' (conversion produces a rune instead of digits)", err)
+	}
+}
+
+func TestConfigurator_Configure_Pointers(t *testing.T) {
+	var port *int
+	err := NewConfigurator().WithMin(1).WithDefault(8080).Configure(&port)
+	if err != nil || port == nil || *port != 8080 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 8080, port, err)
+	}
+	err = NewConfigurator().WithMin(1).WithDefault(80).Configure(&port)
+	if err != nil || *port != 8080 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 8080, *port, err)
+	}
+	var workers **int
+	err = NewConfigurator().WithDefault(4).Configure(&workers)
+	if err != nil || workers == nil || *workers == nil || **workers != 4 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 4, workers, err)
+	}
+	var level *string
+	err = NewConfigurator().WithAllowed(nil, "info").WithDefault("info").Configure(&level)
+	if err != nil || level != nil {
+		t.Errorf("expected '%v', was '%v' ('%v')", nil, level, err)
+	}
+	err = NewConfigurator().WithDisallowed(nil).WithDefault("info").Configure(&level)
+	if err != nil || level == nil || *level != "info" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "info", level, err)
+	}
+	err = NewConfigurator().WithAllowed("info").Configure(new(*string))
+	if err == nil || err.Error() != "configuration error: target value error: argument should be in allowed values ['info']" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: target value error: argument should be in allowed values ['info']", err)
+	}
+	err = NewConfigurator().WithMin(1).Configure(new(*int))
+	if err == nil || err.Error() != "configuration error: target value error: argument should not be nil" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: target value error: argument should not be nil", err)
+	}
+	err = NewConfigurator().WithDefault(false).Configure(new(*int))
+	if err == nil || err.Error() != "configuration error: invalid default value: argument of type 'bool' should be convertible to type 'int'" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: invalid default value: argument of type 'bool' should be convertible to type 'int'", err)
+	}
+	var message string
+	err = NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		message = fmt.Sprintf(format, args...)
+	}).WithMin(1).WithDefault(8080).Configure(new(*int))
+	if err != nil || message != "configuration: min: '1' default: '8080' input: '<nil>' output: '8080'" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "configuration: min: '1' default: '8080' input: '<nil>' output: '8080'", message, err)
+	}
+	type Server struct {
+		Level *string `configuring:"oneof=debug info,default=info"`
+	}
+	server := Server{}
+	err = NewConfigurator().ConfigureStruct(&server)
+	if err != nil || server.Level == nil || *server.Level != "info" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "info", server.Level, err)
+	}
+	report := NewConfigurator().WithDefault(8080).Explain(new(*int))
+	if len(report.Rules) != 1 || report.Rules[0].String() != "not nil: failed: argument should not be nil" || !report.DefaultUsed {
+		t.Errorf("expected '%v', was '%v'", "not nil: failed: argument should not be nil", report.Rules)
+	}
+}
+
+func TestConfigurator_Configure_Interfaces(t *testing.T) {
+	var timeout interface{} = 10 * time.Minute
+	err := NewConfigurator().WithMax("5m").WithDefault("1m").Configure(&timeout)
+	if err != nil || timeout != time.Minute {
+		t.Errorf("expected '%v', was '%v' ('%v')", time.Minute, timeout, err)
+	}
+	var value interface{}
+	err = NewConfigurator().WithDefault(1).Configure(&value)
+	if err != nil || value != 1 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 1, value, err)
+	}
+	value = nil
+	err = NewConfigurator().WithAllowed(nil, 1).WithDefault(1).Configure(&value)
+	if err != nil || value != nil {
+		t.Errorf("expected '%v', was '%v' ('%v')", nil, value, err)
+	}
+	err = NewConfigurator().WithMin(1).Configure(&value)
+	if err == nil || err.Error() != "configuration error: target value error: argument should not be nil" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: target value error: argument should not be nil", err)
+	}
+	values := []interface{}{"a", "", nil}
+	err = NewConfigurator().WithElementConfigurator(NewConfigurator().WithDisallowed("").WithDefault("b")).Configure(&values)
+	if err != nil || !reflect.DeepEqual(values, []interface{}{"a", "b", "b"}) {
+		t.Errorf("expected '%v', was '%v' ('%v')", []interface{}{"a", "b", "b"}, values, err)
+	}
+}
@@ -0,0 +1,116 @@
+package configuring
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type timeoutValidator struct {
+	validator Validator
+	timeout   time.Duration
+}
+
+// Timeout returns validator failing if validation with provided validator is not completed in timeout.
+// Context validators receive context with timeout; other validators keep running in background after timeout.
+func Timeout(validator Validator, timeout time.Duration) Validator {
+	return timeoutValidator{validator: validator, timeout: timeout}
+}
+
+func (v timeoutValidator) Validate(target interface{}) error {
+	return v.ValidateContext(context.Background(), target)
+}
+
+func (v timeoutValidator) ValidateContext(ctx context.Context, target interface{}) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+	if contextValidator, ok := v.validator.(ContextValidator); ok {
+		if err := contextValidator.ValidateContext(timeoutCtx, target); err != nil {
+			if ctx.Err() == nil && timeoutCtx.Err() != nil {
+				return fmt.Errorf("validation should be completed in '%v': %v", v.timeout, err)
+			}
+			return err
+		}
+		return nil
+	}
+	result := make(chan error, 1)
+	go func() {
+		result <- v.validator.Validate(target)
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-timeoutCtx.Done():
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("validation should be completed in '%v': %v", v.timeout, timeoutCtx.Err())
+	}
+}
+
+func (c Configurator) getContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+func (c Configurator) contextErr() error {
+	if c.ctx == nil {
+		return nil
+	}
+	return c.ctx.Err()
+}
+
+// validateWith validates target with validator and context of configurator if validator is a context validator.
+func (c Configurator) validateWith(validator Validator, target interface{}) error {
+	return validateWithContext(c.validatorContext(), validator, target)
+}
+
+// secretContextKey is key of context value marking validated values as secrets.
+type secretContextKey struct{}
+
+// validatorContext returns context of configurator for validators marked with secretContextKey if configurator is secret.
+func (c Configurator) validatorContext() context.Context {
+	if !c.isSecret {
+		return c.ctx
+	}
+	return context.WithValue(c.getContext(), secretContextKey{}, true)
+}
+
+// displayTarget returns target for messages of validators or '*secret*' if context marks values as secrets.
+func displayTarget(ctx context.Context, target interface{}) interface{} {
+	if isSecret, _ := ctx.Value(secretContextKey{}).(bool); isSecret {
+		return "*secret*"
+	}
+	return displayValue(target)
+}
+
+// validateWithContext validates target with validator unless context is done.
+func validateWithContext(ctx context.Context, validator Validator, target interface{}) error {
+	if ctx == nil {
+		ctx = context.Background()
+	} else if err := ctx.Err(); err != nil {
+		return err
+	}
+	return validateContext(ctx, validator, target)
+}
+
+// validateContext validates target with validator and context if validator is a context validator.
+func validateContext(ctx context.Context, validator Validator, target interface{}) error {
+	if contextValidator, ok := validator.(ContextValidator); ok {
+		return contextValidator.ValidateContext(ctx, target)
+	}
+	return validator.Validate(target)
+}
+
+// wrapValidationError returns error of done context as is or wraps validation error.
+func (c Configurator) wrapValidationError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctxErr := c.contextErr(); ctxErr != nil {
+		return ctxErr
+	}
+	return c.wrapError("validation", err)
+}
@@ -0,0 +1,99 @@
+package configuring
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type testContextKey struct{}
+
+type testContextValidator func(ctx context.Context, target interface{}) error
+
+func (fn testContextValidator) Validate(target interface{}) error {
+	return fn(context.Background(), target)
+}
+
+func (fn testContextValidator) ValidateContext(ctx context.Context, target interface{}) error {
+	return fn(ctx, target)
+}
+
+func TestConfigurator_WithValidators_ContextValidator(t *testing.T) {
+	ctx := context.WithValue(context.Background(), testContextKey{}, "value")
+	calls := 0
+	validator := testContextValidator(func(ctx context.Context, target interface{}) error {
+		if value := ctx.Value(testContextKey{}); value != "value" {
+			t.Errorf("expected '%v', was '%v'", "value", value)
+		}
+		calls = calls + 1
+		return nil
+	})
+	err := NewConfigurator().WithContext(ctx).WithValidators(validator).WithLengthValidators(validator).WithElementValidators(validator).Validate([]int{1, 2})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithContext(ctx).WithElementConfigurator(NewConfigurator().WithValidators(validator)).Validate([]int{1})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if calls != 5 {
+		t.Errorf("expected '%v', was '%v'", 5, calls)
+	}
+}
+
+func TestConfigurator_Configure_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	value := 0
+	err := NewConfigurator().WithContext(ctx).WithMin(1).WithDefault(1).Configure(&value)
+	if err != context.Canceled || value != 0 {
+		t.Errorf("expected '%v', was '%v' ('%v')", context.Canceled, err, value)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	values := []int{1, 2, 3}
+	err = NewConfigurator().WithContext(ctx).WithElementConfigurator(NewConfigurator().WithValidators(ValidatorFunc(func(target interface{}) error {
+		cancel()
+		return nil
+	}))).WithDefault([]int{}).Configure(&values)
+	if err != context.Canceled || len(values) != 3 {
+		t.Errorf("expected '%v', was '%v' ('%v')", context.Canceled, err, values)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	err = NewConfigurator().WithContext(ctx).WithValidators(ValidatorFunc(func(target interface{}) error {
+		cancel()
+		return nil
+	}), NewConfigurator().WithMin(10)).Validate(1)
+	if err != context.Canceled {
+		t.Errorf("expected '%v', was '%v'", context.Canceled, err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	err := NewConfigurator().WithValidators(Timeout(ValidatorFunc(func(target interface{}) error {
+		time.Sleep(time.Second)
+		return nil
+	}), 10*time.Millisecond)).Validate(1)
+	if err == nil || err.Error() != "validation error: argument should be validated with validator at index '0': validation should be completed in '10ms': context deadline exceeded" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be validated with validator at index '0': validation should be completed in '10ms': context deadline exceeded", err)
+	}
+	err = NewConfigurator().WithValidators(Timeout(testContextValidator(func(ctx context.Context, target interface{}) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), 10*time.Millisecond)).Validate(1)
+	if err == nil || err.Error() != "validation error: argument should be validated with validator at index '0': validation should be completed in '10ms': context deadline exceeded" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be validated with validator at index '0': validation should be completed in '10ms': context deadline exceeded", err)
+	}
+	err = Timeout(NewConfigurator().WithMax(1), time.Second).Validate(2)
+	if err == nil || err.Error() != "validation error: argument should be lower than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be lower than or equal to '1'", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = NewConfigurator().WithContext(ctx).WithValidators(Timeout(ValidatorFunc(func(target interface{}) error {
+		return nil
+	}), time.Second)).Validate(1)
+	if err != context.Canceled {
+		t.Errorf("expected '%v', was '%v'", context.Canceled, err)
+	}
+}
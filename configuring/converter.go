@@ -0,0 +1,95 @@
+package configuring
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sync"
+	"time"
+)
+
+var (
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+	stringType = reflect.TypeOf("")
+)
+
+var converterRegistry = struct {
+	sync.RWMutex
+	convertFns map[reflect.Type]reflect.Value
+}{
+	convertFns: map[reflect.Type]reflect.Value{},
+}
+
+func init() {
+	RegisterConverter(time.ParseDuration)
+	RegisterConverter(url.Parse)
+	RegisterConverter(regexp.Compile)
+}
+
+// RegisterConverter registers converter function of type 'func(string) (T, error)' for all configurators.
+// Converter converts string values (e.g. min, max, allowed, disallowed and default values) to type T, *T
+// or to type T of converter of *T (e.g. 'url.Parse' converts to 'url.URL' and '*url.URL').
+// Registered converter takes precedence over 'UnmarshalText' method of T and replaces previously registered converter of T.
+// Converters of 'time.Duration' ('time.ParseDuration'), '*url.URL' ('url.Parse') and '*regexp.Regexp' ('regexp.Compile')
+// are registered by default.
+func RegisterConverter(convertFn interface{}) {
+	rConvertFn := reflect.ValueOf(convertFn)
+	if rConvertFn.Kind() != reflect.Func || rConvertFn.IsNil() {
+		panic(fmt.Errorf("invalid converter function type '%T' (type should be 'func(string) (T, error)')", convertFn))
+	}
+	rConvertFnType := rConvertFn.Type()
+	if rConvertFnType.IsVariadic() || rConvertFnType.NumIn() != 1 || rConvertFnType.In(0) != stringType || rConvertFnType.NumOut() != 2 || rConvertFnType.Out(1) != errorType {
+		panic(fmt.Errorf("invalid converter function type '%T' (type should be 'func(string) (T, error)')", convertFn))
+	}
+	converterRegistry.Lock()
+	defer converterRegistry.Unlock()
+	converterRegistry.convertFns[rConvertFnType.Out(0)] = rConvertFn
+}
+
+func findConverter(rTargetType reflect.Type) (reflect.Value, bool) {
+	converterRegistry.RLock()
+	defer converterRegistry.RUnlock()
+	rConvertFn, found := converterRegistry.convertFns[rTargetType]
+	return rConvertFn, found
+}
+
+// convertString converts string to target type with registered converter or 'UnmarshalText' method.
+// It returns false if target type has no converter.
+func convertString(rTargetType reflect.Type, value string) (reflect.Value, bool, error) {
+	if rConvertFn, found := findConverter(rTargetType); found {
+		rResults := rConvertFn.Call([]reflect.Value{reflect.ValueOf(value)})
+		if err, _ := rResults[1].Interface().(error); err != nil {
+			return reflect.Value{}, true, err
+		}
+		return rResults[0], true, nil
+	}
+	if rConvertFn, found := findConverter(reflect.PtrTo(rTargetType)); found {
+		rResults := rConvertFn.Call([]reflect.Value{reflect.ValueOf(value)})
+		if err, _ := rResults[1].Interface().(error); err != nil {
+			return reflect.Value{}, true, err
+		}
+		if rResults[0].IsNil() {
+			return reflect.Value{}, true, fmt.Errorf("converter of type '%v' should not return nil", rConvertFn.Type().String())
+		}
+		return rResults[0].Elem(), true, nil
+	}
+	if reflect.PtrTo(rTargetType).Implements(textUnmarshalerType) {
+		rResult := reflect.New(rTargetType)
+		if err := rResult.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return reflect.Value{}, true, err
+		}
+		return rResult.Elem(), true, nil
+	}
+	if rTargetType.Kind() == reflect.Ptr {
+		rElement, found, err := convertString(rTargetType.Elem(), value)
+		if !found || err != nil {
+			return reflect.Value{}, found, err
+		}
+		rResult := reflect.New(rTargetType.Elem())
+		rResult.Elem().Set(rElement)
+		return rResult, true, nil
+	}
+	return reflect.Value{}, false, nil
+}
@@ -0,0 +1,12 @@
+//go:build go1.18
+// +build go1.18
+
+package configuring
+
+import "net/netip"
+
+func init() {
+	RegisterConverter(netip.ParseAddr)
+	RegisterConverter(netip.ParsePrefix)
+	RegisterConverter(netip.ParseAddrPort)
+}
@@ -0,0 +1,21 @@
+//go:build go1.18
+// +build go1.18
+
+package configuring
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestConfigurator_Configure_NetipConverters(t *testing.T) {
+	address := netip.MustParseAddr("10.0.0.1")
+	err := NewConfigurator().WithMin("127.0.0.1").WithMax("127.255.255.255").WithDefault("127.0.0.1").Configure(&address)
+	if err != nil || address != netip.MustParseAddr("127.0.0.1") {
+		t.Errorf("expected '%v', was '%v' ('%v')", "127.0.0.1", address, err)
+	}
+	err = NewConfigurator().WithAllowed("10.0.0.0/8").Validate(netip.MustParsePrefix("10.0.0.0/8"))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+}
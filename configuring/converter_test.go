@@ -0,0 +1,82 @@
+package configuring
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testByteSize int64
+
+func parseTestByteSize(value string) (testByteSize, error) {
+	if strings.HasSuffix(value, "MiB") {
+		size, err := strconv.ParseInt(strings.TrimSuffix(value, "MiB"), 10, 64)
+		return testByteSize(size << 20), err
+	}
+	size, err := strconv.ParseInt(value, 10, 64)
+	return testByteSize(size), err
+}
+
+func TestRegisterConverter(t *testing.T) {
+	RegisterConverter(parseTestByteSize)
+	err := NewConfigurator().WithMax("10MiB").Validate(testByteSize(10 << 20))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithMax("10MiB").Validate(testByteSize(10<<20 + 1))
+	if err == nil || err.Error() != "validation error: argument should be lower than or equal to '10485760'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be lower than or equal to '10485760'", err)
+	}
+	err = NewConfigurator().WithMax("10GiB").Validate(testByteSize(0))
+	if err == nil || err.Error() != "validation error: invalid max value: argument '10GiB' of type 'string' should be converted to type 'configuring.testByteSize': strconv.ParseInt: parsing \"10GiB\": invalid syntax" {
+		t.Errorf("expected '%v', was '%v'", "validation error: invalid max value: argument '10GiB' of type 'string' should be converted to type 'configuring.testByteSize': strconv.ParseInt: parsing \"10GiB\": invalid syntax", err)
+	}
+	func() {
+		defer func() {
+			if rerr := recover(); rerr == nil || fmt.Sprint(rerr) != "invalid converter function type 'func(string) int' (type should be 'func(string) (T, error)')" {
+				t.Errorf("expected '%v', was '%v'", "invalid converter function type 'func(string) int' (type should be 'func(string) (T, error)')", rerr)
+			}
+		}()
+		RegisterConverter(func(string) int { return 0 })
+	}()
+}
+
+func TestConfigurator_Configure_Converters(t *testing.T) {
+	address := net.IPv4(10, 0, 0, 1)
+	err := NewConfigurator().WithAllowed("127.0.0.1", "::1").WithDefault("127.0.0.1").Configure(&address)
+	if err != nil || address.String() != "127.0.0.1" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "127.0.0.1", address, err)
+	}
+	timeout := time.Duration(0)
+	err = NewConfigurator().WithMin("1s").WithDefault("5s").Configure(&timeout)
+	if err != nil || timeout != 5*time.Second {
+		t.Errorf("expected '%v', was '%v' ('%v')", 5*time.Second, timeout, err)
+	}
+	endpoint, _ := url.Parse("ftp://example.com")
+	err = NewConfigurator().WithAllowed("https://example.com/api").WithDefault("https://example.com/api").Configure(&endpoint)
+	if err != nil || endpoint.String() != "https://example.com/api" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "https://example.com/api", endpoint, err)
+	}
+	err = NewConfigurator().WithDisallowed("https://example.com").Validate(&url.URL{Scheme: "https", Host: "example.com"})
+	if err == nil || err.Error() != "validation error: argument should not be in disallowed values ['https://example.com']" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should not be in disallowed values ['https://example.com']", err)
+	}
+	err = NewConfigurator().WithDefault("^a+$").Configure(new(*regexp.Regexp))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	interval := new(time.Duration)
+	err = NewConfigurator().WithMin("1s").WithDefault("1m").Configure(&interval)
+	if err != nil || *interval != time.Minute {
+		t.Errorf("expected '%v', was '%v' ('%v')", time.Minute, *interval, err)
+	}
+	err = NewConfigurator().WithName("Pattern").WithDefault("(").Configure(new(*regexp.Regexp))
+	if err == nil || err.Error() != "configuration of 'Pattern' error: invalid default value: argument '(' of type 'string' should be converted to type '*regexp.Regexp': error parsing regexp: missing closing ): `(`" {
+		t.Errorf("expected '%v', was '%v'", "configuration of 'Pattern' error: invalid default value: argument '(' of type 'string' should be converted to type '*regexp.Regexp': error parsing regexp: missing closing ): `(`", err)
+	}
+}
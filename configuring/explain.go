@@ -0,0 +1,196 @@
+package configuring
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Report is explanation of configuration made by Configurator.Explain.
+// Values are formatted with log value format of configurator and input, current, normalized and output values of secrets are hidden.
+type Report struct {
+	Name             string       `json:"name,omitempty"`
+	Input            string       `json:"input,omitempty"`
+	Current          string       `json:"current,omitempty"`
+	Normalized       string       `json:"normalized,omitempty"`
+	Rules            []RuleReport `json:"rules,omitempty"`
+	Default          string       `json:"default,omitempty"`
+	DefaultValidated bool         `json:"default_validated"`
+	DefaultError     string       `json:"default_error,omitempty"`
+	DefaultUsed      bool         `json:"default_used"`
+	Fallbacks        string       `json:"fallbacks,omitempty"`
+	Selected         string       `json:"selected,omitempty"`
+	Rejected         []string     `json:"rejected,omitempty"`
+	Warnings         []RuleReport `json:"warnings,omitempty"`
+	Output           string       `json:"output,omitempty"`
+	Error            string       `json:"error,omitempty"`
+}
+
+// RuleReport is result of rule evaluation.
+type RuleReport struct {
+	Severity Severity `json:"severity,omitempty"`
+	Rule     string   `json:"rule"`
+	Value    string   `json:"value,omitempty"`
+	Passed   bool     `json:"passed"`
+	Reason   string   `json:"reason,omitempty"`
+}
+
+func (r Report) String() string {
+	var builder strings.Builder
+	_, _ = builder.WriteString("configuration")
+	if r.Name != "" {
+		_, _ = builder.WriteString(fmt.Sprintf(" of '%v'", r.Name))
+	}
+	_, _ = builder.WriteString(":")
+	if r.Input != "" {
+		_, _ = builder.WriteString(fmt.Sprintf("\n  input: %v", r.Input))
+	}
+	if r.Current != "" {
+		_, _ = builder.WriteString(fmt.Sprintf("\n  current: %v", r.Current))
+	}
+	if r.Normalized != "" {
+		_, _ = builder.WriteString(fmt.Sprintf("\n  normalized: %v", r.Normalized))
+	}
+	for _, rule := range r.Rules {
+		_, _ = builder.WriteString("\n  ")
+		_, _ = builder.WriteString(rule.String())
+	}
+	if r.Default != "" {
+		_, _ = builder.WriteString(fmt.Sprintf("\n  default %v:", r.Default))
+		switch {
+		case r.DefaultError != "":
+			_, _ = builder.WriteString(fmt.Sprintf(" invalid: %v", r.DefaultError))
+		case r.DefaultUsed:
+			_, _ = builder.WriteString(" validated, used")
+		case r.DefaultValidated:
+			_, _ = builder.WriteString(" validated, not used")
+		default:
+			_, _ = builder.WriteString(" not validated")
+		}
+	}
+	if r.Fallbacks != "" {
+		_, _ = builder.WriteString(fmt.Sprintf("\n  fallbacks: %v", r.Fallbacks))
+		for _, rejection := range r.Rejected {
+			_, _ = builder.WriteString(fmt.Sprintf("\n  rejected %v", rejection))
+		}
+		if r.Selected != "" {
+			_, _ = builder.WriteString(fmt.Sprintf("\n  selected: %v", r.Selected))
+		}
+	}
+	for _, warning := range r.Warnings {
+		_, _ = builder.WriteString("\n  ")
+		_, _ = builder.WriteString(warning.String())
+	}
+	if r.Output != "" {
+		_, _ = builder.WriteString(fmt.Sprintf("\n  output: %v", r.Output))
+	}
+	if r.Error != "" {
+		_, _ = builder.WriteString(fmt.Sprintf("\n  error: %v", r.Error))
+	}
+	return builder.String()
+}
+
+func (r RuleReport) String() string {
+	result := r.Rule
+	if r.Severity != SeverityError {
+		result = r.Severity.String() + " " + result
+	}
+	if r.Value != "" {
+		result = result + " " + r.Value
+	}
+	if r.Passed {
+		return result + ": passed"
+	}
+	return result + ": failed: " + r.Reason
+}
+
+// Explain makes configuration of target like Configure without setting result to target and without logging
+// and returns report of every rule evaluation. Rules are evaluated once: rules of input are evaluated until the end
+// to be reported, fallback values are evaluated until the first failure.
+func (c Configurator) Explain(targetPointer interface{}) Report {
+	c.isDryRun = true
+	report := Report{Name: c.qualifiedName()}
+	if err := beConfigurable(targetPointer); err != nil {
+		report.Error = c.wrapError("configuration", c.errorf(MessageNotConfigurable, MessageData{Error: err.Error()}, "target value is not configurable: %v", err)).Error()
+		return report
+	}
+	if optionalTarget, ok := targetPointer.(optionalSetter); ok {
+		c, targetPointer = c.unwrapOptional(optionalTarget)
+	}
+	target := getValue(targetPointer)
+	report.Input = c.formatSecretValue(target)
+	var err error
+	if c, err = c.convert(target); err != nil {
+		report.Error = c.wrapError("configuration", err).Error()
+		return report
+	}
+	if c.currentValue != nil {
+		target = c.currentValue
+		report.Current = c.formatSecretValue(target)
+	}
+	_, result, choice, err := c.configure(target, &report)
+	if len(c.fallbackValues) > 0 {
+		report.Fallbacks = c.formatSecretValues(c.fallbackValues)
+		report.Selected, report.Rejected = choice.candidate, choice.rejections
+	}
+	if err != nil {
+		report.Error = c.wrapError("configuration", err).Error()
+		return report
+	}
+	report.DefaultUsed = choice.candidate == "default"
+	if report.DefaultUsed && c.defaultFn != nil {
+		report.Default = c.formatValue(result)
+		report.DefaultValidated = true
+	}
+	if len(c.advisories) > 0 {
+		report.Warnings = c.advise(result)
+	}
+	report.Output = c.formatSecretValue(result)
+	return report
+}
+
+// addRule adds evaluation of rule to report if report is not nil.
+func (r *Report) addRule(rule string, value string, err error) {
+	if r != nil {
+		r.Rules = append(r.Rules, newRuleReport(rule, value, err))
+	}
+}
+
+func newRuleReport(rule string, value string, err error) RuleReport {
+	if err != nil {
+		return RuleReport{Rule: rule, Value: value, Reason: err.Error()}
+	}
+	return RuleReport{Rule: rule, Value: value, Passed: true}
+}
+
+func (c Configurator) formatValue(value interface{}) string {
+	value = displayValue(value)
+	if c.logValueFormat != "" {
+		return fmt.Sprintf(c.logValueFormat, value)
+	}
+	return fmt.Sprintf("'%v'", value)
+}
+
+func (c Configurator) formatValues(values []interface{}) string {
+	formattedValues := make([]string, len(values))
+	for i, value := range values {
+		formattedValues[i] = c.formatValue(value)
+	}
+	return "[" + strings.Join(formattedValues, ",") + "]"
+}
+
+func (c Configurator) formatSecretValues(values []interface{}) string {
+	if c.isSecret {
+		return "[*secret*" + strings.Repeat(",*secret*", len(values)-1) + "]"
+	}
+	return c.formatValues(values)
+}
+
+func (c Configurator) formatSecretValue(value interface{}) string {
+	if c.isOptional && isNil(value) {
+		return unsetValue
+	}
+	if c.isSecret {
+		return "*secret*"
+	}
+	return c.formatValue(value)
+}
@@ -0,0 +1,98 @@
+package configuring
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestConfigurator_Explain(t *testing.T) {
+	calls := 0
+	port := 0
+	report := NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		calls = calls + 1
+	}).WithName("Port").WithMin(1).WithMax(65535).WithDisallowed(0).WithDefault(8080).Explain(&port)
+	if port != 0 {
+		t.Errorf("expected '%v', was '%v'", 0, port)
+	}
+	if calls != 0 {
+		t.Errorf("expected '%v', was '%v'", 0, calls)
+	}
+	expected := `configuration of 'Port':
+  input: '0'
+  min '1': failed: argument should be greater than or equal to '1'
+  max '65535': passed
+  disallowed ['0']: failed: argument should not be in disallowed values ['0']
+  default '8080': validated, used
+  output: '8080'`
+	if result := report.String(); result != expected {
+		t.Errorf("expected '%v', was '%v'", expected, result)
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	expectedJSON := `{"name":"Port","input":"'0'","rules":[{"rule":"min","value":"'1'","passed":false,"reason":"argument should be greater than or equal to '1'"},{"rule":"max","value":"'65535'","passed":true},{"rule":"disallowed","value":"['0']","passed":false,"reason":"argument should not be in disallowed values ['0']"}],"default":"'8080'","default_validated":true,"default_used":true,"output":"'8080'"}`
+	if result := string(data); result != expectedJSON {
+		t.Errorf("expected '%v', was '%v'", expectedJSON, result)
+	}
+	report = NewConfigurator().WithName("Port").WithMin(1).WithFallbacks(0, 8443).Explain(&port)
+	expected = `configuration of 'Port':
+  input: '0'
+  min '1': failed: argument should be greater than or equal to '1'
+  fallbacks: ['0','8443']
+  rejected input: argument should be greater than or equal to '1'
+  rejected fallback at index '0': argument should be greater than or equal to '1'
+  selected: fallback at index '1'
+  output: '8443'`
+	if result := report.String(); result != expected {
+		t.Errorf("expected '%v', was '%v'", expected, result)
+	}
+	password := "short"
+	report = NewConfigurator().Secret().WithLengthValidators(NewConfigurator().WithMin(8)).WithDefault("x").Explain(&password)
+	expected = `configuration:
+  input: *secret*
+  length validators: failed: argument length should be validated with validator at index '0': validation error: argument should be greater than or equal to '8'
+  default 'x': invalid: argument length should be validated with validator at index '0': validation error: argument should be greater than or equal to '8'
+  error: configuration error: default value error: argument length should be validated with validator at index '0': validation error: argument should be greater than or equal to '8'`
+	if result := report.String(); result != expected {
+		t.Errorf("expected '%v', was '%v'", expected, result)
+	}
+	hosts := []string{"a", ""}
+	report = NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		calls = calls + 1
+	}).WithName("Hosts").WithElementConfigurator(NewConfigurator().WithDisallowed("").WithDefault("localhost")).Explain(&hosts)
+	expected = `configuration of 'Hosts':
+  input: '[a ]'
+  element configuration: passed
+  element configurator: passed
+  output: '[a localhost]'`
+	if result := report.String(); result != expected {
+		t.Errorf("expected '%v', was '%v'", expected, result)
+	}
+	if len(hosts[1]) != 0 || calls != 0 {
+		t.Errorf("expected '%v', was '%v'", []interface{}{"", 0}, []interface{}{hosts[1], calls})
+	}
+	report = NewConfigurator().WithMin("a").Explain(&port)
+	if result := report.String(); result != "configuration:\n  input: '0'\n  error: configuration error: invalid min value: argument of type 'string' should be convertible to type 'int'" {
+		t.Errorf("expected '%v', was '%v'", "configuration:\n  input: '0'\n  error: configuration error: invalid min value: argument of type 'string' should be convertible to type 'int'", result)
+	}
+}
+
+func TestConfigurator_Explain_Evaluations(t *testing.T) {
+	evaluations := 0
+	port := 0
+	report := NewConfigurator().WithValidators(ValidatorFunc(func(target interface{}) error {
+		evaluations = evaluations + 1
+		if target.(int) == 0 {
+			return fmt.Errorf("argument should not be zero")
+		}
+		return nil
+	})).WithMin(1).WithFallbacks(0, 8443).Explain(&port)
+	if report.Output != "'8443'" {
+		t.Errorf("expected '%v', was '%v'", "'8443'", report.Output)
+	}
+	if evaluations != 2 {
+		t.Errorf("expected '%v', was '%v'", 2, evaluations)
+	}
+}
@@ -0,0 +1,226 @@
+package configuring
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Message codes of rule errors and wrapping errors used as keys of message templates.
+const (
+	MessageMin                    = "min"
+	MessageMax                    = "max"
+	MessageAllowed                = "allowed"
+	MessageDisallowed             = "disallowed"
+	MessageValidator              = "validator"
+	MessageLengthValidator        = "length_validator"
+	MessageKeyValidator           = "key_validator"
+	MessageElementValidator       = "element_validator"
+	MessageMapElementValidator    = "map_element_validator"
+	MessageElementConfigurator    = "element_configurator"
+	MessageMapElementConfigurator = "map_element_configurator"
+	MessageTargetValue            = "target_value"
+	MessageDefaultValue           = "default_value"
+	MessageValidation             = "validation"
+	MessageConfiguration          = "configuration"
+	MessageNotNil                 = "not_nil"
+	MessageNotConfigurable        = "not_configurable"
+	MessageInvalidCurrent         = "invalid_current"
+	MessageInvalidDefault         = "invalid_default"
+	MessageInvalidFallbacks       = "invalid_fallbacks"
+	MessageInvalidMin             = "invalid_min"
+	MessageInvalidMax             = "invalid_max"
+	MessageInvalidAllowed         = "invalid_allowed"
+	MessageInvalidDisallowed      = "invalid_disallowed"
+	MessageUnexpectedLength       = "unexpected_length_validators"
+	MessageUnexpectedKeys         = "unexpected_key_validators"
+	MessageUnexpectedElements     = "unexpected_element_validators"
+	MessageUnexpectedConfigurator = "unexpected_element_configurator"
+)
+
+// MessageData is data of message templates.
+type MessageData struct {
+	// Name is name of configuration.
+	Name string
+	// Value is validated value or '*secret*' for secrets.
+	Value interface{}
+	// Bound is min or max value or list of allowed or disallowed values.
+	Bound interface{}
+	// Index is index or key of element or key of map.
+	Index interface{}
+	// Validator is index of validator.
+	Validator int
+	// Error is message of nested error.
+	Error string
+}
+
+var messageRegistry = struct {
+	sync.RWMutex
+	templates map[string]map[string]*template.Template
+}{
+	templates: map[string]map[string]*template.Template{},
+}
+
+func init() {
+	RegisterMessages("de", map[string]string{
+		MessageMin:                    "Argument sollte größer oder gleich '{{.Bound}}' sein",
+		MessageMax:                    "Argument sollte kleiner oder gleich '{{.Bound}}' sein",
+		MessageAllowed:                "Argument sollte einer der erlaubten Werte [{{.Bound}}] sein",
+		MessageDisallowed:             "Argument sollte keiner der unzulässigen Werte [{{.Bound}}] sein",
+		MessageValidator:              "Argument sollte mit Validator an Index '{{.Validator}}' validiert werden: {{.Error}}",
+		MessageLengthValidator:        "Argumentlänge sollte mit Validator an Index '{{.Validator}}' validiert werden: {{.Error}}",
+		MessageKeyValidator:           "Argumentschlüssel '{{.Index}}' sollte mit Validator an Index '{{.Validator}}' validiert werden: {{.Error}}",
+		MessageElementValidator:       "Argumentelement an Index '{{.Index}}' sollte mit Validator an Index '{{.Validator}}' validiert werden: {{.Error}}",
+		MessageMapElementValidator:    "Argumentelement mit Schlüssel '{{.Index}}' sollte mit Validator an Index '{{.Validator}}' validiert werden: {{.Error}}",
+		MessageElementConfigurator:    "Argumentelement an Index '{{.Index}}' sollte mit Elementkonfigurator validiert werden: {{.Error}}",
+		MessageMapElementConfigurator: "Argumentelement mit Schlüssel '{{.Index}}' sollte mit Elementkonfigurator validiert werden: {{.Error}}",
+		MessageTargetValue:            "Fehler des Zielwerts: {{.Error}}",
+		MessageDefaultValue:           "Fehler des Standardwerts: {{.Error}}",
+		MessageValidation:             "{{if .Name}}Fehler der Validierung von '{{.Name}}'{{else}}Fehler der Validierung{{end}}: {{.Error}}",
+		MessageConfiguration:          "{{if .Name}}Fehler der Konfiguration von '{{.Name}}'{{else}}Fehler der Konfiguration{{end}}: {{.Error}}",
+		MessageNotNil:                 "Argument sollte nicht nil sein",
+		MessageNotConfigurable:        "Zielwert ist nicht konfigurierbar: {{.Error}}",
+		MessageInvalidCurrent:         "ungültiger aktueller Wert: {{.Error}}",
+		MessageInvalidDefault:         "ungültiger Standardwert: {{.Error}}",
+		MessageInvalidFallbacks:       "ungültige Ersatzwerte: {{.Error}}",
+		MessageInvalidMin:             "ungültiger Minimalwert: {{.Error}}",
+		MessageInvalidMax:             "ungültiger Maximalwert: {{.Error}}",
+		MessageInvalidAllowed:         "ungültige erlaubte Werte: {{.Error}}",
+		MessageInvalidDisallowed:      "ungültige unzulässige Werte: {{.Error}}",
+		MessageUnexpectedLength:       "unerwartete Längenvalidatoren: {{.Error}}",
+		MessageUnexpectedKeys:         "unerwartete Schlüsselvalidatoren: {{.Error}}",
+		MessageUnexpectedElements:     "unerwartete Elementvalidatoren: {{.Error}}",
+		MessageUnexpectedConfigurator: "unerwarteter Elementkonfigurator: {{.Error}}",
+	})
+	RegisterMessages("ru", map[string]string{
+		MessageMin:                    "аргумент должен быть больше или равен '{{.Bound}}'",
+		MessageMax:                    "аргумент должен быть меньше или равен '{{.Bound}}'",
+		MessageAllowed:                "аргумент должен быть одним из допустимых значений [{{.Bound}}]",
+		MessageDisallowed:             "аргумент не должен быть одним из недопустимых значений [{{.Bound}}]",
+		MessageValidator:              "аргумент должен пройти проверку валидатором с индексом '{{.Validator}}': {{.Error}}",
+		MessageLengthValidator:        "длина аргумента должна пройти проверку валидатором с индексом '{{.Validator}}': {{.Error}}",
+		MessageKeyValidator:           "ключ аргумента '{{.Index}}' должен пройти проверку валидатором с индексом '{{.Validator}}': {{.Error}}",
+		MessageElementValidator:       "элемент аргумента с индексом '{{.Index}}' должен пройти проверку валидатором с индексом '{{.Validator}}': {{.Error}}",
+		MessageMapElementValidator:    "элемент аргумента с ключом '{{.Index}}' должен пройти проверку валидатором с индексом '{{.Validator}}': {{.Error}}",
+		MessageElementConfigurator:    "элемент аргумента с индексом '{{.Index}}' должен пройти проверку конфигуратором элементов: {{.Error}}",
+		MessageMapElementConfigurator: "элемент аргумента с ключом '{{.Index}}' должен пройти проверку конфигуратором элементов: {{.Error}}",
+		MessageTargetValue:            "ошибка значения: {{.Error}}",
+		MessageDefaultValue:           "ошибка значения по умолчанию: {{.Error}}",
+		MessageValidation:             "{{if .Name}}ошибка проверки '{{.Name}}'{{else}}ошибка проверки{{end}}: {{.Error}}",
+		MessageConfiguration:          "{{if .Name}}ошибка конфигурации '{{.Name}}'{{else}}ошибка конфигурации{{end}}: {{.Error}}",
+		MessageNotNil:                 "аргумент не должен быть nil",
+		MessageNotConfigurable:        "значение не может быть сконфигурировано: {{.Error}}",
+		MessageInvalidCurrent:         "некорректное текущее значение: {{.Error}}",
+		MessageInvalidDefault:         "некорректное значение по умолчанию: {{.Error}}",
+		MessageInvalidFallbacks:       "некорректные резервные значения: {{.Error}}",
+		MessageInvalidMin:             "некорректное минимальное значение: {{.Error}}",
+		MessageInvalidMax:             "некорректное максимальное значение: {{.Error}}",
+		MessageInvalidAllowed:         "некорректные допустимые значения: {{.Error}}",
+		MessageInvalidDisallowed:      "некорректные недопустимые значения: {{.Error}}",
+		MessageUnexpectedLength:       "неожиданные валидаторы длины: {{.Error}}",
+		MessageUnexpectedKeys:         "неожиданные валидаторы ключей: {{.Error}}",
+		MessageUnexpectedElements:     "неожиданные валидаторы элементов: {{.Error}}",
+		MessageUnexpectedConfigurator: "неожиданный конфигуратор элементов: {{.Error}}",
+	})
+}
+
+// RegisterMessages registers message templates of locale by message codes for all configurators.
+// Templates are parsed with 'text/template' and executed with MessageData.
+// Registered templates replace previously registered templates of the same locale and codes.
+// German ('de') and Russian ('ru') messages are registered by default, English messages are used if no template found.
+func RegisterMessages(locale string, messages map[string]string) {
+	templates := parseMessages(messages)
+	messageRegistry.Lock()
+	defer messageRegistry.Unlock()
+	if messageRegistry.templates[locale] == nil {
+		messageRegistry.templates[locale] = map[string]*template.Template{}
+	}
+	for code, messageTemplate := range templates {
+		messageRegistry.templates[locale][code] = messageTemplate
+	}
+}
+
+// WithLocale provides locale of messages registered with RegisterMessages.
+func (c Configurator) WithLocale(locale string) Configurator {
+	c.locale = locale
+	return c
+}
+
+// WithMessages provides message templates by message codes (see RegisterMessages).
+// They take precedence over messages of locale.
+func (c Configurator) WithMessages(messages map[string]string) Configurator {
+	templates := make(map[string]*template.Template, len(c.messages)+len(messages))
+	for code, messageTemplate := range c.messages {
+		templates[code] = messageTemplate
+	}
+	for code, messageTemplate := range parseMessages(messages) {
+		templates[code] = messageTemplate
+	}
+	c.messages = templates
+	return c
+}
+
+// WithMessage provides message template of rule by message code (see RegisterMessages).
+func (c Configurator) WithMessage(code string, messageTemplate string) Configurator {
+	return c.WithMessages(map[string]string{code: messageTemplate})
+}
+
+func parseMessages(messages map[string]string) map[string]*template.Template {
+	templates := make(map[string]*template.Template, len(messages))
+	for code, message := range messages {
+		messageTemplate, err := template.New(code).Parse(message)
+		if err != nil {
+			panic(fmt.Errorf("invalid message template of code '%v': %v", code, err))
+		}
+		templates[code] = messageTemplate
+	}
+	return templates
+}
+
+func (c Configurator) findMessage(code string) (*template.Template, bool) {
+	if messageTemplate, found := c.messages[code]; found {
+		return messageTemplate, true
+	}
+	if c.locale == "" {
+		return nil, false
+	}
+	messageRegistry.RLock()
+	defer messageRegistry.RUnlock()
+	messageTemplate, found := messageRegistry.templates[c.locale][code]
+	return messageTemplate, found
+}
+
+// errorf returns error with message of template of code or with default message if no template found.
+// If template could not be executed, error of template execution is reported with default message.
+func (c Configurator) errorf(code string, data MessageData, format string, args ...interface{}) error {
+	var templateErr error
+	if messageTemplate, found := c.findMessage(code); found {
+		data.Name = c.qualifiedName()
+		if c.isSecret {
+			data.Value = "*secret*"
+		}
+		var buffer bytes.Buffer
+		if templateErr = messageTemplate.Execute(&buffer, data); templateErr == nil {
+			return errors.New(buffer.String())
+		}
+	}
+	for i := range args {
+		args[i] = displayValue(args[i])
+	}
+	if templateErr != nil {
+		return fmt.Errorf("%v (message template of code '%v' error: %v)", fmt.Sprintf(format, args...), code, templateErr)
+	}
+	return fmt.Errorf(format, args...)
+}
+
+// formatList formats values as quoted comma-separated list.
+func formatList(values []interface{}) string {
+	displayValues := make([]interface{}, len(values))
+	for i, value := range values {
+		displayValues[i] = displayValue(value)
+	}
+	return fmt.Sprintf("'%v'"+strings.Repeat(",'%v'", len(values)-1), displayValues...)
+}
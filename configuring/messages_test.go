@@ -0,0 +1,81 @@
+package configuring
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestConfigurator_WithLocale(t *testing.T) {
+	port := 0
+	err := NewConfigurator().WithLocale("de").WithName("Port").WithMin(1).Configure(&port)
+	if err == nil || err.Error() != "Fehler der Konfiguration von 'Port': Fehler des Zielwerts: Argument sollte größer oder gleich '1' sein" {
+		t.Errorf("expected '%v', was '%v'", "Fehler der Konfiguration von 'Port': Fehler des Zielwerts: Argument sollte größer oder gleich '1' sein", err)
+	}
+	err = NewConfigurator().WithLocale("ru").WithAllowed("a", "b").Validate("c")
+	if err == nil || err.Error() != "ошибка проверки: аргумент должен быть одним из допустимых значений ['a','b']" {
+		t.Errorf("expected '%v', was '%v'", "ошибка проверки: аргумент должен быть одним из допустимых значений ['a','b']", err)
+	}
+	err = NewConfigurator().WithLocale("ru").WithElementConfigurator(NewConfigurator().WithMax(1)).Validate(map[string]int{"a": 2})
+	if err == nil || err.Error() != "ошибка проверки: элемент аргумента с ключом 'a' должен пройти проверку конфигуратором элементов: ошибка проверки: аргумент должен быть меньше или равен '1'" {
+		t.Errorf("expected '%v', was '%v'", "ошибка проверки: элемент аргумента с ключом 'a' должен пройти проверку конфигуратором элементов: ошибка проверки: аргумент должен быть меньше или равен '1'", err)
+	}
+	err = NewConfigurator().WithLocale("fr").WithMax(1).Validate(2)
+	if err == nil || err.Error() != "validation error: argument should be lower than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be lower than or equal to '1'", err)
+	}
+}
+
+func TestConfigurator_WithMessage(t *testing.T) {
+	err := NewConfigurator().WithName("Port").WithLocale("de").WithMessage(MessageMax, "{{.Name}} darf höchstens {{.Bound}} sein, nicht {{.Value}}").WithMax(10).Validate(11)
+	if err == nil || err.Error() != "Fehler der Validierung von 'Port': Port darf höchstens 10 sein, nicht 11" {
+		t.Errorf("expected '%v', was '%v'", "Fehler der Validierung von 'Port': Port darf höchstens 10 sein, nicht 11", err)
+	}
+	err = NewConfigurator().Secret().WithMessages(map[string]string{MessageValidation: "{{.Error}}", MessageDisallowed: "value {{.Value}} is not allowed"}).WithDisallowed("").Validate("")
+	if err == nil || err.Error() != "value *secret* is not allowed" {
+		t.Errorf("expected '%v', was '%v'", "value *secret* is not allowed", err)
+	}
+	err = NewConfigurator().WithMessage(MessageElementValidator, "element {{.Index}}: {{.Error}}").WithMessage(MessageValidation, "{{.Error}}").WithElementValidators(NewConfigurator().WithMin(0)).Validate([]int{0, -1})
+	if err == nil || err.Error() != "element 1: validation error: argument should be greater than or equal to '0'" {
+		t.Errorf("expected '%v', was '%v'", "element 1: validation error: argument should be greater than or equal to '0'", err)
+	}
+	func() {
+		defer func() {
+			if rerr := recover(); rerr == nil || !strings.HasPrefix(fmt.Sprint(rerr), "invalid message template of code 'min': template: min:1: ") {
+				t.Errorf("expected '%v', was '%v'", "invalid message template of code 'min': template: min:1: ...", rerr)
+			}
+		}()
+		NewConfigurator().WithMessage(MessageMin, "{{.Bound}")
+	}()
+}
+
+func TestRegisterMessages(t *testing.T) {
+	RegisterMessages("test", map[string]string{MessageMin: "too small"})
+	err := NewConfigurator().WithLocale("test").WithMin(1).Validate(0)
+	if err == nil || err.Error() != "validation error: too small" {
+		t.Errorf("expected '%v', was '%v'", "validation error: too small", err)
+	}
+}
+
+func TestConfigurator_WithLocale_RuleErrors(t *testing.T) {
+	err := NewConfigurator().WithLocale("de").WithMin(1).Validate((*int)(nil))
+	if err == nil || err.Error() != "Fehler der Validierung: Argument sollte nicht nil sein" {
+		t.Errorf("expected '%v', was '%v'", "Fehler der Validierung: Argument sollte nicht nil sein", err)
+	}
+	port := 0
+	err = NewConfigurator().WithLocale("ru").WithMin("a").Configure(&port)
+	if err == nil || err.Error() != "ошибка конфигурации: некорректное минимальное значение: argument of type 'string' should be convertible to type 'int'" {
+		t.Errorf("expected '%v', was '%v'", "ошибка конфигурации: некорректное минимальное значение: argument of type 'string' should be convertible to type 'int'", err)
+	}
+	err = NewConfigurator().WithLocale("de").WithKeyValidators(NewConfigurator()).Configure(&port)
+	if err == nil || err.Error() != "Fehler der Konfiguration: unerwartete Schlüsselvalidatoren: argument of type 'int' should be a map" {
+		t.Errorf("expected '%v', was '%v'", "Fehler der Konfiguration: unerwartete Schlüsselvalidatoren: argument of type 'int' should be a map", err)
+	}
+}
+
+func TestConfigurator_WithMessage_ExecutionError(t *testing.T) {
+	err := NewConfigurator().WithMessage(MessageMin, "{{.Error.Missing}}").WithMin(1).Validate(0)
+	if err == nil || !strings.HasPrefix(err.Error(), "validation error: argument should be greater than or equal to '1' (message template of code 'min' error: template: min:1:") {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be greater than or equal to '1' (message template of code 'min' error: template: min:1: ...)", err)
+	}
+}
@@ -0,0 +1,171 @@
+package configuring
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Normalizer normalizes values before validation (e.g. trims whitespaces or sorts elements).
+type Normalizer interface {
+	Normalize(target interface{}) (interface{}, error)
+}
+
+// NormalizerFunc is normalizer function.
+// Unicode normalization is not provided since it requires 'golang.org/x/text', but it may be wrapped,
+// e.g. NFC normalization with 'golang.org/x/text/unicode/norm':
+//
+//	NormalizerFunc(func(target interface{}) (interface{}, error) { return norm.NFC.String(target.(string)), nil })
+type NormalizerFunc func(target interface{}) (interface{}, error)
+
+func (fn NormalizerFunc) Normalize(target interface{}) (interface{}, error) {
+	return fn(target)
+}
+
+// Normalizers of strings and slices. Pointers are normalized by copies of values they point to.
+var (
+	// TrimSpace removes leading and trailing white spaces of strings.
+	TrimSpace Normalizer = stringNormalizer(strings.TrimSpace)
+	// ToLower maps strings to lower case.
+	ToLower Normalizer = stringNormalizer(strings.ToLower)
+	// ToUpper maps strings to upper case.
+	ToUpper Normalizer = stringNormalizer(strings.ToUpper)
+	// CleanPath cleans paths of strings with 'filepath.Clean' (empty strings are not changed).
+	CleanPath Normalizer = stringNormalizer(func(value string) string {
+		if value == "" {
+			return value
+		}
+		return filepath.Clean(value)
+	})
+	// Unique removes duplicated elements of slices keeping first occurrences.
+	// Elements are compared with equalers and comparators of configurator (see WithEqualer and WithComparator).
+	Unique Normalizer = comparingNormalizer(func(c Configurator, rTarget reflect.Value) (reflect.Value, error) {
+		if rTarget.Kind() != reflect.Slice {
+			return reflect.Value{}, fmt.Errorf("argument of type '%v' should be a slice", rTarget.Type().String())
+		}
+		if rTarget.IsNil() {
+			return rTarget, nil
+		}
+		rResult := reflect.MakeSlice(rTarget.Type(), 0, rTarget.Len())
+		for i := 0; i < rTarget.Len(); i++ {
+			element := rTarget.Index(i).Interface()
+			duplicated := false
+			for j := 0; j < rResult.Len() && !duplicated; j++ {
+				duplicated = c.equal(element, rResult.Index(j).Interface())
+			}
+			if !duplicated {
+				rResult = reflect.Append(rResult, rTarget.Index(i))
+			}
+		}
+		return rResult, nil
+	})
+	// Sort sorts elements of slices in ascending order.
+	// Elements are compared with comparators of configurator (see WithComparator).
+	Sort Normalizer = comparingNormalizer(func(c Configurator, rTarget reflect.Value) (reflect.Value, error) {
+		if rTarget.Kind() != reflect.Slice {
+			return reflect.Value{}, fmt.Errorf("argument of type '%v' should be a slice", rTarget.Type().String())
+		}
+		if rTarget.IsNil() {
+			return rTarget, nil
+		}
+		rResult := reflect.MakeSlice(rTarget.Type(), rTarget.Len(), rTarget.Len())
+		reflect.Copy(rResult, rTarget)
+		var err error
+		sort.SliceStable(rResult.Interface(), func(i, j int) bool {
+			result, compareErr := c.compare(rResult.Index(i).Interface(), rResult.Index(j).Interface())
+			if compareErr != nil && err == nil {
+				err = compareErr
+			}
+			return result < 0
+		})
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return rResult, nil
+	})
+)
+
+// comparingNormalizer is normalizer comparing values with comparison of configurator it is provided to.
+// Called directly it compares values like configurator without comparators.
+type comparingNormalizer func(c Configurator, rTarget reflect.Value) (reflect.Value, error)
+
+func (fn comparingNormalizer) Normalize(target interface{}) (interface{}, error) {
+	return fn.normalizeWith(NewConfigurator(), target)
+}
+
+func (fn comparingNormalizer) normalizeWith(c Configurator, target interface{}) (interface{}, error) {
+	return normalizeIndirect(target, func(rTarget reflect.Value) (reflect.Value, error) {
+		return fn(c, rTarget)
+	})
+}
+
+func stringNormalizer(normalizeFn func(value string) string) Normalizer {
+	return NormalizerFunc(func(target interface{}) (interface{}, error) {
+		return normalizeIndirect(target, func(rTarget reflect.Value) (reflect.Value, error) {
+			if rTarget.Kind() != reflect.String {
+				return reflect.Value{}, fmt.Errorf("argument of type '%v' should be a string", rTarget.Type().String())
+			}
+			return reflect.ValueOf(normalizeFn(rTarget.String())).Convert(rTarget.Type()), nil
+		})
+	})
+}
+
+// normalizeIndirect normalizes value target points to and returns pointer to normalized copy of value.
+func normalizeIndirect(target interface{}, normalizeFn func(rTarget reflect.Value) (reflect.Value, error)) (interface{}, error) {
+	rTarget := reflect.ValueOf(target)
+	if !rTarget.IsValid() {
+		return nil, fmt.Errorf("argument should not be nil")
+	}
+	rResult, err := normalizeValue(rTarget, normalizeFn)
+	if err != nil {
+		return nil, err
+	}
+	return rResult.Interface(), nil
+}
+
+func normalizeValue(rTarget reflect.Value, normalizeFn func(rTarget reflect.Value) (reflect.Value, error)) (reflect.Value, error) {
+	if rTarget.Kind() != reflect.Ptr {
+		return normalizeFn(rTarget)
+	}
+	if rTarget.IsNil() {
+		return rTarget, nil
+	}
+	rElement, err := normalizeValue(rTarget.Elem(), normalizeFn)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	rResult := reflect.New(rTarget.Type().Elem())
+	rResult.Elem().Set(rElement)
+	return rResult, nil
+}
+
+// WithNormalizers provides normalizers of target. They are called in order after conversion and before validation
+// and normalized value is configured if it is valid.
+func (c Configurator) WithNormalizers(normalizers ...Normalizer) Configurator {
+	var result []Normalizer
+	result = append(result, c.normalizers...)
+	result = append(result, normalizers...)
+	c.normalizers = result
+	return c
+}
+
+func (c Configurator) normalize(target interface{}) (interface{}, error) {
+	for i, normalizer := range c.normalizers {
+		var normalized interface{}
+		var err error
+		if comparingNormalizer, ok := normalizer.(comparingNormalizer); ok {
+			normalized, err = comparingNormalizer.normalizeWith(c, target)
+		} else {
+			normalized, err = normalizer.Normalize(target)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("argument should be normalized with normalizer at index '%v': %v", i, err)
+		}
+		if target, err = convert(target, normalized); err != nil {
+			return nil, fmt.Errorf("argument should be normalized with normalizer at index '%v': invalid normalized value: %v", i, err)
+		}
+	}
+	return target, nil
+}
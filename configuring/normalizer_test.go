@@ -0,0 +1,87 @@
+package configuring
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestConfigurator_WithNormalizers(t *testing.T) {
+	calls := 0
+	level := "  DEBUG "
+	err := NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		if message := fmt.Sprintf(format, args...); message != "configuration of 'Level': allowed: ['debug','info'] input: '  DEBUG ' normalized: 'debug' output: 'debug'" {
+			t.Errorf("expected '%v', was '%v'", "configuration of 'Level': allowed: ['debug','info'] input: '  DEBUG ' normalized: 'debug' output: 'debug'", message)
+		}
+		calls = calls + 1
+	}).WithName("Level").WithNormalizers(TrimSpace, ToLower).WithAllowed("debug", "info").Configure(&level)
+	if err != nil || level != "debug" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "debug", level, err)
+	}
+	if calls != 1 {
+		t.Errorf("expected '%v', was '%v'", 1, calls)
+	}
+	level = "TRACE"
+	err = NewConfigurator().WithNormalizers(ToLower).WithAllowed("debug", "info").WithDefault("info").Configure(&level)
+	if err != nil || level != "info" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "info", level, err)
+	}
+	err = NewConfigurator().WithNormalizers(ToUpper).WithAllowed("INFO").Validate("info")
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	path := "/etc/app/../app/config.yaml"
+	pathPointer := &path
+	err = NewConfigurator().WithNormalizers(CleanPath).Configure(&pathPointer)
+	if err != nil || *pathPointer != "/etc/app/config.yaml" || path != "/etc/app/../app/config.yaml" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "/etc/app/config.yaml", *pathPointer, err)
+	}
+	hosts := []string{"b", "a", "b", "c", "a"}
+	err = NewConfigurator().WithNormalizers(Unique, Sort).WithLengthValidators(NewConfigurator().WithMax(3)).Configure(&hosts)
+	if result := fmt.Sprint(hosts); err != nil || result != "[a b c]" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "[a b c]", result, err)
+	}
+	err = NewConfigurator().WithName("Port").WithNormalizers(TrimSpace).Configure(new(int))
+	if err == nil || err.Error() != "configuration of 'Port' error: target value error: argument should be normalized with normalizer at index '0': argument of type 'int' should be a string" {
+		t.Errorf("expected '%v', was '%v'", "configuration of 'Port' error: target value error: argument should be normalized with normalizer at index '0': argument of type 'int' should be a string", err)
+	}
+	err = NewConfigurator().WithNormalizers(NormalizerFunc(func(target interface{}) (interface{}, error) {
+		return nil, errors.New("invalid value")
+	})).WithDefault(1).Configure(new(int))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithNormalizers(NormalizerFunc(func(target interface{}) (interface{}, error) {
+		return "a", nil
+	})).Validate(1)
+	if err == nil || err.Error() != "validation error: argument should be normalized with normalizer at index '0': invalid normalized value: argument of type 'string' should be convertible to type 'int'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be normalized with normalizer at index '0': invalid normalized value: argument of type 'string' should be convertible to type 'int'", err)
+	}
+	err = NewConfigurator().WithNormalizers(Sort).Validate("b")
+	if err == nil || err.Error() != "validation error: argument should be normalized with normalizer at index '0': argument of type 'string' should be a slice" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be normalized with normalizer at index '0': argument of type 'string' should be a slice", err)
+	}
+	report := NewConfigurator().WithNormalizers(TrimSpace).WithDisallowed("").Explain(&level)
+	if result := report.String(); result != "configuration:\n  input: 'info'\n  normalized: 'info'\n  normalization: passed\n  disallowed ['']: passed\n  output: 'info'" {
+		t.Errorf("expected '%v', was '%v'", "configuration:\n  input: 'info'\n  normalized: 'info'\n  normalization: passed\n  disallowed ['']: passed\n  output: 'info'", result)
+	}
+}
+
+func TestConfigurator_WithNormalizers_Comparison(t *testing.T) {
+	versions := []testVersion{{1, 10}, {1, 2}, {0, 9}}
+	err := NewConfigurator().WithComparator(compareTestVersions).WithNormalizers(Sort).Configure(&versions)
+	if result := fmt.Sprint(versions); err != nil || result != "[{0 9} {1 2} {1 10}]" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "[{0 9} {1 2} {1 10}]", result, err)
+	}
+	levels := []string{"info", "INFO", "debug"}
+	err = NewConfigurator().WithEqualer(strings.EqualFold).WithNormalizers(Unique).Configure(&levels)
+	if result := fmt.Sprint(levels); err != nil || result != "[info debug]" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "[info debug]", result, err)
+	}
+	names := []string{"b", "a"}
+	normalized, err := Sort.Normalize(names)
+	if result := fmt.Sprint(normalized); err != nil || result != "[a b]" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "[a b]", result, err)
+	}
+}
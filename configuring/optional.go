@@ -0,0 +1,55 @@
+package configuring
+
+import "reflect"
+
+// unsetValue is representation of unset optional values in logs and reports.
+const unsetValue = "<unset>"
+
+// optional is implemented by Optional values. Optional value is configured as a pointer to its value (nil if unset).
+type optional interface {
+	optionalPointer() interface{}
+}
+
+var optionalType = reflect.TypeOf((*optional)(nil)).Elem()
+
+// optionalValueType returns type of pointer to value of optional type (e.g. '*int' for 'Optional[int]').
+func optionalValueType(rOptionalType reflect.Type) reflect.Type {
+	return reflect.TypeOf(reflect.Zero(rOptionalType).Interface().(optional).optionalPointer()).Elem()
+}
+
+// optionalSetter is implemented by pointers to Optional values.
+type optionalSetter interface {
+	optional
+	setOptionalPointer(pointer interface{})
+}
+
+// unwrapOptional returns configurator and pointer to pointer to value of optional target.
+// Fallback and default values are not applied to set values.
+func (c Configurator) unwrapOptional(target optional) (Configurator, interface{}) {
+	valuePointer := target.optionalPointer()
+	c.isOptional = true
+	if !isNil(getValue(valuePointer)) {
+		c.defaultValue, c.defaultFn, c.fallbackValues = nil, nil, nil
+	}
+	return c, valuePointer
+}
+
+// configureOptional configures value of optional target like pointer target.
+func (c Configurator) configureOptional(targetPointer optionalSetter, report *Report) error {
+	c, valuePointer := c.unwrapOptional(targetPointer)
+	if err := c.configureWithReport(valuePointer, report); err != nil {
+		return err
+	}
+	targetPointer.setOptionalPointer(valuePointer)
+	return nil
+}
+
+// validateOptional validates value of optional target. Unset values are valid.
+func (c Configurator) validateOptional(target optional) error {
+	c, valuePointer := c.unwrapOptional(target)
+	value := getValue(valuePointer)
+	if isNil(value) {
+		return nil
+	}
+	return c.Validate(value)
+}
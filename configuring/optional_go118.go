@@ -0,0 +1,129 @@
+//go:build go1.18
+// +build go1.18
+
+package configuring
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Optional is configuration value which distinguishes unset value from zero value. Zero Optional is unset.
+// Configurator applies fallback and default values only to unset optionals and validates values of set optionals
+// without replacing invalid values (see Configurator.Configure).
+type Optional[T any] struct {
+	value T
+	isSet bool
+}
+
+// Some returns set optional of value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, isSet: true}
+}
+
+// Get returns value and whether it is set.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.isSet
+}
+
+// IsSet checks whether value is set.
+func (o Optional[T]) IsSet() bool {
+	return o.isSet
+}
+
+// OrElse returns value if it is set or provided value otherwise.
+func (o Optional[T]) OrElse(value T) T {
+	if o.isSet {
+		return o.value
+	}
+	return value
+}
+
+// Set sets value.
+func (o *Optional[T]) Set(value T) {
+	*o = Some(value)
+}
+
+// Unset unsets value.
+func (o *Optional[T]) Unset() {
+	*o = Optional[T]{}
+}
+
+func (o Optional[T]) String() string {
+	if !o.isSet {
+		return unsetValue
+	}
+	return fmt.Sprint(o.value)
+}
+
+// MarshalJSON marshals unset value as null.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.isSet {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON unmarshals null as unset value.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		o.Unset()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	o.Set(value)
+	return nil
+}
+
+// MarshalYAML marshals unset value as null (see 'gopkg.in/yaml.v2' and 'gopkg.in/yaml.v3' marshalers).
+func (o Optional[T]) MarshalYAML() (interface{}, error) {
+	if !o.isSet {
+		return nil, nil
+	}
+	return o.value, nil
+}
+
+// UnmarshalYAML unmarshals value (see 'gopkg.in/yaml.v2' and 'gopkg.in/yaml.v3' obsolete unmarshalers).
+func (o *Optional[T]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value *T
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+	if value == nil {
+		o.Unset()
+		return nil
+	}
+	o.Set(*value)
+	return nil
+}
+
+// UnmarshalText parses text as literal of rule language (see Configurator.WithRules) and sets value.
+func (o *Optional[T]) UnmarshalText(text []byte) error {
+	var value T
+	if err := parseLiteralTo(reflect.ValueOf(&value).Elem(), string(text)); err != nil {
+		return err
+	}
+	o.Set(value)
+	return nil
+}
+
+func (o Optional[T]) optionalPointer() interface{} {
+	var valuePointer *T
+	if o.isSet {
+		value := o.value
+		valuePointer = &value
+	}
+	return &valuePointer
+}
+
+func (o *Optional[T]) setOptionalPointer(pointer interface{}) {
+	if valuePointer := *pointer.(**T); valuePointer != nil {
+		o.Set(*valuePointer)
+	} else {
+		o.Unset()
+	}
+}
@@ -0,0 +1,211 @@
+//go:build go1.18
+// +build go1.18
+
+package configuring
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigurator_Configure_Optional(t *testing.T) {
+	var messages []string
+	logger := func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}
+	var retries Optional[int]
+	err := NewConfigurator().WithLogger(logger).WithMin(1).WithDefault(3).Configure(&retries)
+	if value, isSet := retries.Get(); err != nil || !isSet || value != 3 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 3, retries, err)
+	}
+	retries = Some(0)
+	err = NewConfigurator().WithLogger(logger).WithMax(5).WithDefault(3).Configure(&retries)
+	if value, isSet := retries.Get(); err != nil || !isSet || value != 0 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 0, retries, err)
+	}
+	err = NewConfigurator().WithLogger(logger).WithName("Retries").WithMin(1).WithDefault(3).Configure(&retries)
+	if err == nil || err.Error() != "configuration of 'Retries' error: target value error: argument should be greater than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "configuration of 'Retries' error: target value error: argument should be greater than or equal to '1'", err)
+	}
+	if value, _ := retries.Get(); value != 0 {
+		t.Errorf("expected '%v', was '%v'", 0, retries)
+	}
+	retries.Unset()
+	err = NewConfigurator().WithLogger(logger).WithMin(1).Configure(&retries)
+	if err != nil || retries.IsSet() {
+		t.Errorf("expected '%v', was '%v' ('%v')", unsetValue, retries, err)
+	}
+	expected := []string{
+		"configuration: min: '1' default: '3' input: <unset> output: '3'",
+		"configuration: max: '5' input: '0' output: '0'",
+		"configuration: min: '1' input: <unset> output: <unset>",
+	}
+	if fmt.Sprint(messages) != fmt.Sprint(expected) {
+		t.Errorf("expected '%v', was '%v'", expected, messages)
+	}
+	timeout := Optional[time.Duration]{}
+	err = NewConfigurator().WithFallbacks(nil, "5s").WithDefault("1m").Configure(&timeout)
+	if err != nil || timeout.OrElse(0) != 5*time.Second {
+		t.Errorf("expected '%v', was '%v' ('%v')", 5*time.Second, timeout, err)
+	}
+	report := NewConfigurator().WithMin(1).Explain(&Optional[int]{})
+	if report.String() != "configuration:\n  input: <unset>\n  output: <unset>" {
+		t.Errorf("expected '%v', was '%v'", "configuration:\n  input: <unset>\n  output: <unset>", report.String())
+	}
+	type Config struct {
+		Retries Optional[int] `configuring:"min=1,default=3"`
+	}
+	config := Config{}
+	err = NewConfigurator().ConfigureStruct(&config)
+	if err != nil || config.Retries != Some(3) {
+		t.Errorf("expected '%v', was '%v' ('%v')", Some(3), config.Retries, err)
+	}
+}
+
+func TestConfigurator_Validate_Optional(t *testing.T) {
+	err := NewConfigurator().WithMin(1).Validate(Optional[int]{})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithMin(1).Validate(Some(0))
+	if err == nil || err.Error() != "validation error: argument should be greater than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be greater than or equal to '1'", err)
+	}
+}
+
+func TestConfigurator_WithSeverityRules_Optional(t *testing.T) {
+	var messages []string
+	configurator := NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}).WithSeverityRules(SeverityWarn, NewConfigurator().WithMax(5))
+	var retries Optional[int]
+	report, err := configurator.ConfigureWithReport(&retries)
+	if err != nil || len(report.Warnings) != 0 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 0, report.Warnings, err)
+	}
+	if report := configurator.Explain(&retries); len(report.Warnings) != 0 {
+		t.Errorf("expected '%v', was '%v'", 0, report.Warnings)
+	}
+	retries = Some(6)
+	report, err = configurator.ConfigureWithReport(&retries)
+	if err != nil || len(report.Warnings) != 1 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 1, report.Warnings, err)
+	}
+	for _, message := range messages {
+		if strings.Contains(message, "should not be nil") {
+			t.Errorf("expected '%v', was '%v'", "no nil warnings", messages)
+		}
+	}
+}
+
+func TestPlan_Optional(t *testing.T) {
+	plan, err := NewConfigurator().WithName("Retries").WithMin(1).WithDefault(3).Compile(reflect.TypeOf(Optional[int]{}))
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	if err = plan.Validate(Optional[int]{}); err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = plan.Validate(Some(0))
+	if err == nil || err.Error() != "validation of 'Retries' error: argument should be greater than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "validation of 'Retries' error: argument should be greater than or equal to '1'", err)
+	}
+	var retries Optional[int]
+	err = plan.Configure(&retries)
+	if err != nil || retries != Some(3) {
+		t.Errorf("expected '%v', was '%v' ('%v')", Some(3), retries, err)
+	}
+	retries = Some(0)
+	err = plan.Configure(&retries)
+	if err == nil || err.Error() != "configuration of 'Retries' error: target value error: argument should be greater than or equal to '1'" || retries != Some(0) {
+		t.Errorf("expected '%v', was '%v'", "configuration of 'Retries' error: target value error: argument should be greater than or equal to '1'", err)
+	}
+	retries = Some(5)
+	err = plan.Configure(&retries)
+	if err != nil || retries != Some(5) {
+		t.Errorf("expected '%v', was '%v' ('%v')", Some(5), retries, err)
+	}
+}
+
+func TestStructSchema_Optional(t *testing.T) {
+	type Server struct {
+		Port    Optional[int]           `json:"port" configuring:"min=1,default=8080"`
+		Timeout Optional[time.Duration] `json:"timeout"`
+	}
+	schema, err := StructSchema(&Server{})
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := toJSON(t, schema); result != `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"port":{"default":8080,"minimum":1,"title":"Port","type":"integer"},"timeout":{"type":"string"}},"type":"object"}` {
+		t.Errorf("expected '%v', was '%v'", `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"port":{"default":8080,"minimum":1,"title":"Port","type":"integer"},"timeout":{"type":"string"}},"type":"object"}`, result)
+	}
+	schema, err = NewConfigurator().WithMax(10).Schema(Optional[int]{})
+	if result := toJSON(t, schema); err != nil || result != `{"$schema":"https://json-schema.org/draft/2020-12/schema","maximum":10,"type":"integer"}` {
+		t.Errorf("expected '%v', was '%v' ('%v')", `{"$schema":"https://json-schema.org/draft/2020-12/schema","maximum":10,"type":"integer"}`, result, err)
+	}
+}
+
+func TestOptional_JSON(t *testing.T) {
+	type Config struct {
+		Retries Optional[int] `json:"retries"`
+		Timeout Optional[int] `json:"timeout"`
+		Workers Optional[int] `json:"workers"`
+	}
+	config := Config{Workers: Some(4)}
+	err := json.Unmarshal([]byte(`{"retries":0,"workers":null}`), &config)
+	if err != nil || config.Retries != Some(0) || config.Timeout.IsSet() || config.Workers.IsSet() {
+		t.Errorf("expected '%v', was '%v' ('%v')", Config{Retries: Some(0)}, config, err)
+	}
+	data, err := json.Marshal(config)
+	if err != nil || string(data) != `{"retries":0,"timeout":null,"workers":null}` {
+		t.Errorf("expected '%v', was '%v' ('%v')", `{"retries":0,"timeout":null,"workers":null}`, string(data), err)
+	}
+	err = json.Unmarshal([]byte(`{"retries":"a"}`), &config)
+	if err == nil {
+		t.Errorf("expected '%v', was '%v'", "error", err)
+	}
+}
+
+func TestOptional_UnmarshalYAML(t *testing.T) {
+	var retries Optional[int]
+	err := retries.UnmarshalYAML(func(value interface{}) error {
+		number := 5
+		*value.(**int) = &number
+		return nil
+	})
+	if err != nil || retries != Some(5) {
+		t.Errorf("expected '%v', was '%v' ('%v')", Some(5), retries, err)
+	}
+	err = retries.UnmarshalYAML(func(value interface{}) error {
+		return nil
+	})
+	if err != nil || retries.IsSet() {
+		t.Errorf("expected '%v', was '%v' ('%v')", unsetValue, retries, err)
+	}
+	if value, err := Some(5).MarshalYAML(); err != nil || value != 5 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 5, value, err)
+	}
+	if value, err := retries.MarshalYAML(); err != nil || value != nil {
+		t.Errorf("expected '%v', was '%v' ('%v')", nil, value, err)
+	}
+}
+
+func TestOptional_UnmarshalText(t *testing.T) {
+	var timeout Optional[time.Duration]
+	err := timeout.UnmarshalText([]byte("5s"))
+	if err != nil || timeout != Some(5*time.Second) {
+		t.Errorf("expected '%v', was '%v' ('%v')", Some(5*time.Second), timeout, err)
+	}
+	var retries Optional[int]
+	err = retries.UnmarshalText([]byte("a"))
+	if err == nil || err.Error() != "strconv.ParseInt: parsing \"a\": invalid syntax" || retries.IsSet() {
+		t.Errorf("expected '%v', was '%v'", "strconv.ParseInt: parsing \"a\": invalid syntax", err)
+	}
+	if result := retries.String(); result != "<unset>" {
+		t.Errorf("expected '%v', was '%v'", "<unset>", result)
+	}
+}
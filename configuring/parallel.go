@@ -0,0 +1,113 @@
+package configuring
+
+import (
+	"reflect"
+	"sync"
+)
+
+type elementJob struct {
+	index   int
+	rKey    reflect.Value
+	element interface{}
+}
+
+// validateEachElement calls validateFn for elements of target sequentially or with parallelism workers
+// and returns error of the first invalid element: elements of maps are ordered by keys and other elements by indexes.
+// Maps are iterated without sorting keys, so after invalid element is found, elements with greater keys are skipped
+// and elements with lower keys are still validated to report the same element on every call.
+// Panic of validateFn in worker skips remaining elements and is raised again on the calling goroutine like sequential validation.
+func (c Configurator) validateEachElement(target interface{}, validateFn func(index int, rKey reflect.Value, element interface{}) error) error {
+	if c.parallelism < 2 {
+		return c.validateEachElementSequentially(target, validateFn)
+	}
+	ctx := c.getContext()
+	var mutex sync.Mutex
+	failed, err := elementJob{}, error(nil)
+	panicked, panicValue := false, interface{}(nil)
+	// failedBefore reports that element before job is invalid or validation panicked, so job can be skipped.
+	failedBefore := func(job elementJob) bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return panicked || err != nil && c.isElementBefore(failed, job)
+	}
+	// validateJob validates element of job and recovers panic of validateFn to raise it on the calling goroutine.
+	validateJob := func(job elementJob) error {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				mutex.Lock()
+				if !panicked {
+					panicked, panicValue = true, recovered
+				}
+				mutex.Unlock()
+			}
+		}()
+		return validateFn(job.index, job.rKey, job.element)
+	}
+	jobs := make(chan elementJob)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < c.parallelism; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for job := range jobs {
+				if ctx.Err() != nil || failedBefore(job) {
+					continue
+				}
+				if jobErr := validateJob(job); jobErr != nil {
+					mutex.Lock()
+					if err == nil || c.isElementBefore(job, failed) {
+						failed, err = job, jobErr
+					}
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	forEachElement(target, func(index int, rKey reflect.Value, element interface{}) bool {
+		job := elementJob{index: index, rKey: rKey, element: element}
+		if failedBefore(job) {
+			return rKey.IsValid()
+		}
+		select {
+		case jobs <- job:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+	close(jobs)
+	waitGroup.Wait()
+	if panicked {
+		panic(panicValue)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// validateEachElementSequentially calls validateFn for elements of target and returns error of the first invalid element
+// like validateEachElement.
+func (c Configurator) validateEachElementSequentially(target interface{}, validateFn func(index int, rKey reflect.Value, element interface{}) error) error {
+	failed, err := elementJob{}, error(nil)
+	forEachElement(target, func(index int, rKey reflect.Value, element interface{}) bool {
+		job := elementJob{index: index, rKey: rKey}
+		if err != nil && !c.isElementBefore(job, failed) {
+			return true
+		}
+		if jobErr := validateFn(index, rKey, element); jobErr != nil {
+			failed, err = job, jobErr
+			return rKey.IsValid()
+		}
+		return true
+	})
+	return err
+}
+
+// isElementBefore reports that element of job1 is before element of job2: map elements are ordered by keys and others by indexes.
+func (c Configurator) isElementBefore(job1 elementJob, job2 elementJob) bool {
+	if job1.rKey.IsValid() {
+		return c.lessKey(job1.rKey, job2.rKey)
+	}
+	return job1.index < job2.index
+}
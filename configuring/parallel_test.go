@@ -0,0 +1,87 @@
+package configuring
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConfigurator_WithParallelism(t *testing.T) {
+	target := make([]int, 1000)
+	for i := range target {
+		target[i] = i
+	}
+	configurator := NewConfigurator().WithParallelism(8).WithElementValidators(NewConfigurator().WithDisallowed(100, 500, 900))
+	for i := 0; i < 20; i++ {
+		err := configurator.Validate(target)
+		if err == nil || err.Error() != "validation error: argument element at index '100' should be validated with validator at index '0': validation error: argument should not be in disallowed values ['100','500','900']" {
+			t.Errorf("expected '%v', was '%v'", "validation error: argument element at index '100' should be validated with validator at index '0': validation error: argument should not be in disallowed values ['100','500','900']", err)
+		}
+	}
+	err := NewConfigurator().WithParallelism(4).WithElementConfigurator(NewConfigurator().WithMax(10)).Validate(map[string]int{"d": 20, "b": 11, "a": 1, "c": 30})
+	if err == nil || err.Error() != "validation error: argument element with key 'b' should be validated with element configurator: validation error: argument should be lower than or equal to '10'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument element with key 'b' should be validated with element configurator: validation error: argument should be lower than or equal to '10'", err)
+	}
+	var calls int32
+	err = NewConfigurator().WithParallelism(4).WithElementValidators(ValidatorFunc(func(target interface{}) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})).Validate(target)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if calls != 1000 {
+		t.Errorf("expected '%v', was '%v'", 1000, calls)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	err = NewConfigurator().WithContext(ctx).WithParallelism(4).WithElementValidators(ValidatorFunc(func(target interface{}) error {
+		if target == 10 {
+			cancel()
+		}
+		return nil
+	})).Validate(target)
+	if err != context.Canceled {
+		t.Errorf("expected '%v', was '%v'", context.Canceled, err)
+	}
+	err = NewConfigurator().WithParallelism(4).WithElementValidators(ValidatorFunc(func(target interface{}) error {
+		return errors.New("invalid")
+	})).Validate([]int(nil))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+}
+
+func TestConfigurator_validateEachElement_Map(t *testing.T) {
+	target := make(map[int]int, 100)
+	for i := 0; i < 100; i++ {
+		target[i] = i
+	}
+	for _, parallelism := range []int{1, 4} {
+		for i := 0; i < 10; i++ {
+			err := NewConfigurator().WithParallelism(parallelism).WithElementValidators(NewConfigurator().WithMin(90)).Validate(target)
+			if err == nil || err.Error() != "validation error: argument element with key '0' should be validated with validator at index '0': validation error: argument should be greater than or equal to '90'" {
+				t.Errorf("expected '%v', was '%v'", "validation error: argument element with key '0' should be validated with validator at index '0': validation error: argument should be greater than or equal to '90'", err)
+			}
+			err = NewConfigurator().WithParallelism(parallelism).WithKeyValidators(NewConfigurator().WithMax(5)).Validate(target)
+			if err == nil || err.Error() != "validation error: argument key '6' should be validated with validator at index '0': validation error: argument should be lower than or equal to '5'" {
+				t.Errorf("expected '%v', was '%v'", "validation error: argument key '6' should be validated with validator at index '0': validation error: argument should be lower than or equal to '5'", err)
+			}
+		}
+	}
+}
+
+func TestConfigurator_WithParallelism_Panic(t *testing.T) {
+	for _, target := range []interface{}{make([]int, 1000), map[int]int{1: 1, 2: 2, 3: 3, 4: 4, 5: 5}} {
+		func() {
+			defer func() {
+				if recovered := recover(); recovered != "invalid validator" {
+					t.Errorf("expected '%v', was '%v'", "invalid validator", recovered)
+				}
+			}()
+			NewConfigurator().WithParallelism(4).WithElementValidators(ValidatorFunc(func(target interface{}) error {
+				panic("invalid validator")
+			})).Validate(target)
+		}()
+	}
+}
@@ -0,0 +1,70 @@
+package configuring
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Names of standard presets.
+const (
+	// PresetPort is TCP port in range [1, 65535].
+	PresetPort = "port"
+	// PresetPositiveDuration is duration greater than zero.
+	PresetPositiveDuration = "positive_duration"
+	// PresetLogLevel is log level of ['debug','info','warn','error'] normalized to lower case.
+	PresetLogLevel = "log_level"
+	// PresetPercentage is number in range [0, 100].
+	PresetPercentage = "percentage"
+	// PresetNonEmptyString is string which is not empty.
+	PresetNonEmptyString = "non_empty_string"
+	// PresetAbsolutePath is absolute path cleaned with 'filepath.Clean'.
+	PresetAbsolutePath = "absolute_path"
+)
+
+var presetRegistry = struct {
+	sync.RWMutex
+	configurators map[string]Configurator
+}{
+	configurators: map[string]Configurator{},
+}
+
+func init() {
+	RegisterPreset(PresetPort, NewConfigurator().WithMin(1).WithMax(65535))
+	RegisterPreset(PresetPositiveDuration, NewConfigurator().WithMin(time.Nanosecond))
+	RegisterPreset(PresetLogLevel, NewConfigurator().WithNormalizers(TrimSpace, ToLower).WithAllowed("debug", "info", "warn", "error"))
+	RegisterPreset(PresetPercentage, NewConfigurator().WithMin(0).WithMax(100))
+	RegisterPreset(PresetNonEmptyString, NewConfigurator().WithDisallowed(""))
+	RegisterPreset(PresetAbsolutePath, NewConfigurator().WithNormalizers(CleanPath).WithValidators(ValidatorFunc(func(target interface{}) error {
+		rTarget := indirect(target)
+		if rTarget.Kind() != reflect.String {
+			return fmt.Errorf("argument of type '%T' should be a string", target)
+		}
+		if !filepath.IsAbs(rTarget.String()) {
+			return fmt.Errorf("argument '%v' should be an absolute path", rTarget.String())
+		}
+		return nil
+	})))
+}
+
+// RegisterPreset registers configurator as preset of name for all configurators.
+// Registered preset replaces previously registered preset of the same name, so standard presets may be overridden per process.
+func RegisterPreset(name string, configurator Configurator) {
+	presetRegistry.Lock()
+	defer presetRegistry.Unlock()
+	presetRegistry.configurators[name] = configurator
+}
+
+// Preset returns configurator registered with RegisterPreset (e.g. Preset(PresetPort).WithName("HTTPPort").WithDefault(8080)).
+// It panics if no preset of name is registered.
+func Preset(name string) Configurator {
+	presetRegistry.RLock()
+	defer presetRegistry.RUnlock()
+	configurator, found := presetRegistry.configurators[name]
+	if !found {
+		panic(fmt.Errorf("preset '%v' is not registered", name))
+	}
+	return configurator
+}
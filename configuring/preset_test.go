@@ -0,0 +1,84 @@
+package configuring
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreset(t *testing.T) {
+	port := 0
+	err := Preset(PresetPort).WithName("HTTPPort").WithDefault(8080).Configure(&port)
+	if err != nil || port != 8080 {
+		t.Errorf("expected '%v', was '%v' ('%v')", 8080, port, err)
+	}
+	err = Preset(PresetPort).WithName("HTTPPort").Validate(70000)
+	if err == nil || err.Error() != "validation of 'HTTPPort' error: argument should be lower than or equal to '65535'" {
+		t.Errorf("expected '%v', was '%v'", "validation of 'HTTPPort' error: argument should be lower than or equal to '65535'", err)
+	}
+	err = Preset(PresetPositiveDuration).Validate(time.Duration(0))
+	if err == nil || err.Error() != "validation error: argument should be greater than or equal to '1ns'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be greater than or equal to '1ns'", err)
+	}
+	level := " DEBUG "
+	err = Preset(PresetLogLevel).WithDefault("info").Configure(&level)
+	if err != nil || level != "debug" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "debug", level, err)
+	}
+	err = Preset(PresetPercentage).Validate(100.5)
+	if err == nil || err.Error() != "validation error: argument should be lower than or equal to '100'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be lower than or equal to '100'", err)
+	}
+	err = Preset(PresetNonEmptyString).Validate("")
+	if err == nil || err.Error() != "validation error: argument should not be in disallowed values ['']" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should not be in disallowed values ['']", err)
+	}
+	path := "/var/log/../lib/"
+	err = Preset(PresetAbsolutePath).Configure(&path)
+	if err != nil || path != "/var/lib" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "/var/lib", path, err)
+	}
+	err = Preset(PresetAbsolutePath).Validate("var/lib")
+	if err == nil || err.Error() != "validation error: argument should be validated with validator at index '0': argument 'var/lib' should be an absolute path" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be validated with validator at index '0': argument 'var/lib' should be an absolute path", err)
+	}
+	type Path string
+	logPath := Path("/var/log/./app")
+	err = Preset(PresetAbsolutePath).Configure(&logPath)
+	if err != nil || logPath != "/var/log/app" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "/var/log/app", logPath, err)
+	}
+	err = Preset(PresetAbsolutePath).Validate(Path("log"))
+	if err == nil || err.Error() != "validation error: argument should be validated with validator at index '0': argument 'log' should be an absolute path" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be validated with validator at index '0': argument 'log' should be an absolute path", err)
+	}
+	relativePath := "var/lib"
+	pathPointer := &relativePath
+	err = Preset(PresetAbsolutePath).Configure(&pathPointer)
+	if err == nil || err.Error() != "configuration error: target value error: argument should be validated with validator at index '0': argument 'var/lib' should be an absolute path" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: target value error: argument should be validated with validator at index '0': argument 'var/lib' should be an absolute path", err)
+	}
+	path = "/var/lib"
+	pathPointer = &path
+	err = Preset(PresetAbsolutePath).Configure(&pathPointer)
+	if err != nil || *pathPointer != "/var/lib" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "/var/lib", *pathPointer, err)
+	}
+}
+
+func TestRegisterPreset(t *testing.T) {
+	defer RegisterPreset(PresetPort, Preset(PresetPort))
+	RegisterPreset(PresetPort, NewConfigurator().WithMin(1024).WithMax(65535))
+	err := Preset(PresetPort).Validate(80)
+	if err == nil || err.Error() != "validation error: argument should be greater than or equal to '1024'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be greater than or equal to '1024'", err)
+	}
+	func() {
+		defer func() {
+			rerr := recover()
+			if err, ok := rerr.(error); !ok || err == nil || err.Error() != "preset 'unknown' is not registered" {
+				t.Errorf("expected '%v', was '%v'", "preset 'unknown' is not registered", rerr)
+			}
+		}()
+		Preset("unknown")
+	}()
+}
@@ -0,0 +1,16 @@
+//go:build !go1.12
+// +build !go1.12
+
+package configuring
+
+import "reflect"
+
+// rangeMap calls fn for keys and elements of map in unspecified order until fn returns false.
+// Keys are collected with 'MapKeys' since 'MapRange' requires Go 1.12.
+func rangeMap(rMap reflect.Value, fn func(rKey reflect.Value, rElement reflect.Value) bool) {
+	for _, rKey := range rMap.MapKeys() {
+		if !fn(rKey, rMap.MapIndex(rKey)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,15 @@
+//go:build go1.12
+// +build go1.12
+
+package configuring
+
+import "reflect"
+
+// rangeMap calls fn for keys and elements of map in unspecified order until fn returns false.
+func rangeMap(rMap reflect.Value, fn func(rKey reflect.Value, rElement reflect.Value) bool) {
+	for iterator := rMap.MapRange(); iterator.Next(); {
+		if !fn(iterator.Key(), iterator.Value()) {
+			return
+		}
+	}
+}
@@ -3,11 +3,17 @@ package configuring
 import (
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
 )
 
+// Method names discovered for equality and ordering of values.
+// Changing them affects all configurators of the process, so prefer Configurator.WithComparator,
+// Configurator.WithEqualer, RegisterComparator and RegisterEqualer for specific types.
 var (
 	EqualMethodNames   = []string{"Equal"}
+	CompareMethodNames = []string{"Compare", "Cmp"}
 	GreaterMethodNames = []string{"Greater", "After"}
 	LowerMethodNames   = []string{"Lower", "Before"}
 )
@@ -31,20 +37,161 @@ func getValue(pointer interface{}) interface{} {
 }
 
 func setValue(pointer interface{}, value interface{}) {
-	reflect.ValueOf(pointer).Elem().Set(reflect.ValueOf(value))
+	rTarget := reflect.ValueOf(pointer).Elem()
+	if value == nil {
+		rTarget.Set(reflect.Zero(rTarget.Type()))
+		return
+	}
+	rTarget.Set(reflect.ValueOf(value))
 }
 
+// convert converts value to type of target.
+// Values are not converted for nil interface targets, which have no dynamic type.
+// Nil values are converted to nil of pointer, interface, slice, map, channel and function types
+// and values of element types are converted to pointers to copies of values.
 func convert(target interface{}, value interface{}) (interface{}, error) {
+	if target == nil {
+		return value, nil
+	}
+	return convertToType(reflect.TypeOf(target), value)
+}
+
+func convertToType(rTargetType reflect.Type, value interface{}) (interface{}, error) {
 	rValue := reflect.ValueOf(value)
 	if !rValue.IsValid() {
-		return nil, errors.New("argument should not be nil")
+		if !isNillable(rTargetType.Kind()) {
+			return nil, errors.New("argument should not be nil")
+		}
+		return reflect.Zero(rTargetType).Interface(), nil
+	}
+	if rTargetType.Kind() == reflect.Interface {
+		if !reflect.TypeOf(value).Implements(rTargetType) {
+			return nil, fmt.Errorf("argument of type '%v' should implement type '%v'", reflect.TypeOf(value).String(), rTargetType.String())
+		}
+		return value, nil
+	}
+	if literal, ok := value.(ruleLiteral); ok {
+		return parseLiteral(rTargetType, string(literal))
 	}
-	rTargetType := reflect.TypeOf(target)
 	rValueType := reflect.TypeOf(value)
+	if rValue.Kind() == reflect.String && rValueType != rTargetType {
+		if rResult, found, err := convertString(rTargetType, rValue.String()); found {
+			if err != nil {
+				return nil, fmt.Errorf("argument '%v' of type '%v' should be converted to type '%v': %v", value, rValueType.String(), rTargetType.String(), err)
+			}
+			return rResult.Interface(), nil
+		}
+	}
 	if !rValueType.ConvertibleTo(rTargetType) {
+		if rTargetType.Kind() == reflect.Ptr && rValueType.Kind() != reflect.Ptr {
+			element, err := convertToType(rTargetType.Elem(), value)
+			if err != nil {
+				return nil, err
+			}
+			rResult := reflect.New(rTargetType.Elem())
+			if element != nil {
+				rResult.Elem().Set(reflect.ValueOf(element))
+			}
+			return rResult.Interface(), nil
+		}
 		return nil, fmt.Errorf("argument of type '%v' should be convertible to type '%v'", rValueType.String(), rTargetType.String())
 	}
-	return rValue.Convert(rTargetType).Interface(), nil
+	rResult := rValue.Convert(rTargetType)
+	if err := beLosslessConversion(rValue, rResult); err != nil {
+		return nil, err
+	}
+	return rResult.Interface(), nil
+}
+
+// beLosslessConversion checks that number conversion neither overflows nor loses precision
+// and that integer is not converted to string of a rune.
+func beLosslessConversion(rValue reflect.Value, rResult reflect.Value) error {
+	valueKind, resultKind := getNumberKind(rValue.Kind()), getNumberKind(rResult.Kind())
+	if valueKind != reflect.Invalid && valueKind != reflect.Float64 && rResult.Kind() == reflect.String {
+		return fmt.Errorf("argument '%v' of type '%v' should not be converted to type '%v' (conversion produces a rune instead of digits)", rValue.Interface(), rValue.Type().String(), rResult.Type().String())
+	}
+	if valueKind == reflect.Invalid || resultKind == reflect.Invalid {
+		return nil
+	}
+	if valueKind == reflect.Float64 && resultKind == reflect.Float64 {
+		if value, result := rValue.Float(), rResult.Float(); math.IsInf(result, 0) && !math.IsInf(value, 0) {
+			return fmt.Errorf("argument '%v' of type '%v' overflows type '%v'", rValue.Interface(), rValue.Type().String(), rResult.Type().String())
+		}
+		return nil
+	}
+	if rResult.Convert(rValue.Type()).Interface() == rValue.Interface() && getNumberSign(rValue) == getNumberSign(rResult) {
+		return nil
+	}
+	if valueKind == reflect.Float64 && math.Trunc(rValue.Float()) != rValue.Float() || resultKind == reflect.Float64 {
+		return fmt.Errorf("argument '%v' of type '%v' loses precision on conversion to type '%v'", rValue.Interface(), rValue.Type().String(), rResult.Type().String())
+	}
+	return fmt.Errorf("argument '%v' of type '%v' overflows type '%v'", rValue.Interface(), rValue.Type().String(), rResult.Type().String())
+}
+
+func isNillable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		return true
+	}
+	return false
+}
+
+// isNil checks that target is nil interface or nil pointer.
+func isNil(target interface{}) bool {
+	if target == nil {
+		return true
+	}
+	rTarget := reflect.ValueOf(target)
+	return rTarget.Kind() == reflect.Ptr && rTarget.IsNil()
+}
+
+// displayValue dereferences non-nil pointers printed as addresses (pointers to values except structs, arrays, slices and maps
+// without 'String' and 'Error' methods) for messages.
+func displayValue(value interface{}) interface{} {
+	switch value.(type) {
+	case fmt.Stringer, error:
+		return value
+	}
+	rValue := reflect.ValueOf(value)
+	if rValue.Kind() != reflect.Ptr || rValue.IsNil() {
+		return value
+	}
+	switch rValue.Elem().Kind() {
+	case reflect.Struct, reflect.Array, reflect.Slice, reflect.Map:
+		return value
+	}
+	return displayValue(rValue.Elem().Interface())
+}
+
+// getNumberKind returns reflect.Int64, reflect.Uint64 or reflect.Float64 for number kinds and reflect.Invalid for others.
+func getNumberKind(kind reflect.Kind) reflect.Kind {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint64
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return reflect.Invalid
+}
+
+func getNumberSign(rValue reflect.Value) int {
+	switch getNumberKind(rValue.Kind()) {
+	case reflect.Int64:
+		return normalizeComparison(rValue.Int())
+	case reflect.Uint64:
+		if rValue.Uint() > 0 {
+			return 1
+		}
+	case reflect.Float64:
+		if value := rValue.Float(); value > 0 {
+			return 1
+		} else if value < 0 {
+			return -1
+		}
+	}
+	return 0
 }
 
 func convertNotNil(target interface{}, value interface{}) (interface{}, error) {
@@ -70,45 +217,78 @@ func convertArray(target interface{}, values []interface{}) ([]interface{}, erro
 }
 
 func callMethodBool(methodName string, rTarget reflect.Value, rValue reflect.Value) (bool, error) {
+	rResult, err := callMethod(methodName, rTarget, rValue, reflect.TypeOf(bool(true)))
+	if err != nil {
+		return false, err
+	}
+	return rResult.Bool(), nil
+}
+
+func callMethodInt(methodName string, rTarget reflect.Value, rValue reflect.Value) (int, error) {
+	rResult, err := callMethod(methodName, rTarget, rValue, reflect.TypeOf(int(0)))
+	if err != nil {
+		return 0, err
+	}
+	return normalizeComparison(rResult.Int()), nil
+}
+
+func callMethod(methodName string, rTarget reflect.Value, rValue reflect.Value, rResultType reflect.Type) (reflect.Value, error) {
 	if rTarget.Kind() == reflect.Ptr {
 		if rTarget.IsNil() {
-			return false, fmt.Errorf("method '%v' should not be called with nil receiver", methodName)
+			return reflect.Value{}, fmt.Errorf("method '%v' should not be called with nil receiver", methodName)
 		}
-		return false, fmt.Errorf("method '%v' should not be called with nil argument", methodName)
+		return reflect.Value{}, fmt.Errorf("method '%v' should not be called with nil argument", methodName)
 	}
-	rTargetType := rTarget.Type()
-	rResultType := reflect.TypeOf(bool(true))
+	methodFn, err := findMethod(methodName, rTarget.Type(), rResultType)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return methodFn(rTarget, rValue), nil
+}
+
+// findMethod finds method of target type or of pointer to target type that accepts argument of target type
+// or of pointer to target type and returns function calling it with values of target type.
+func findMethod(methodName string, rTargetType reflect.Type, rResultType reflect.Type) (func(rTarget reflect.Value, rValue reflect.Value) reflect.Value, error) {
 	rTargetPointerType := reflect.PtrTo(rTargetType)
 	if rMethod, found := rTargetType.MethodByName(methodName); found {
 		if rMethod.Type.IsVariadic() || rMethod.Type.NumIn() != 2 || !rMethod.Type.In(1).AssignableTo(rTargetType) && !rMethod.Type.In(1).AssignableTo(rTargetPointerType) || rMethod.Type.NumOut() != 1 || !rMethod.Type.Out(0).AssignableTo(rResultType) {
-			return false, fmt.Errorf("method '%v' of type '%v' should accept argument of type '%v' or '%v' and should return result of type '%v'", methodName, rTargetType.String(), rTargetType.String(), rTargetPointerType.String(), rResultType.String())
+			return nil, fmt.Errorf("method '%v' of type '%v' should accept argument of type '%v' or '%v' and should return result of type '%v'", methodName, rTargetType.String(), rTargetType.String(), rTargetPointerType.String(), rResultType.String())
 		}
 		if rMethod.Type.In(1).AssignableTo(rTargetType) {
-			return rMethod.Func.Call([]reflect.Value{rTarget, rValue})[0].Bool(), nil
+			return func(rTarget reflect.Value, rValue reflect.Value) reflect.Value {
+				return rMethod.Func.Call([]reflect.Value{rTarget, rValue})[0]
+			}, nil
 		}
-		rValuePointer := reflect.New(rTargetType)
-		rValuePointer.Elem().Set(rValue)
-		return rMethod.Func.Call([]reflect.Value{rTarget, rValuePointer})[0].Bool(), nil
+		return func(rTarget reflect.Value, rValue reflect.Value) reflect.Value {
+			rValuePointer := reflect.New(rTargetType)
+			rValuePointer.Elem().Set(rValue)
+			return rMethod.Func.Call([]reflect.Value{rTarget, rValuePointer})[0]
+		}, nil
 	}
 	if rMethod, found := rTargetPointerType.MethodByName(methodName); found {
 		if rMethod.Type.IsVariadic() || rMethod.Type.NumIn() != 2 || !rMethod.Type.In(1).AssignableTo(rTargetType) && !rMethod.Type.In(1).AssignableTo(rTargetPointerType) || rMethod.Type.NumOut() != 1 || !rMethod.Type.Out(0).AssignableTo(rResultType) {
-			return false, fmt.Errorf("method '%v' of type '%v' should accept argument of type '%v' or '%v' and should return result of type '%v'", methodName, rTargetPointerType.String(), rTargetType.String(), rTargetPointerType.String(), rResultType.String())
+			return nil, fmt.Errorf("method '%v' of type '%v' should accept argument of type '%v' or '%v' and should return result of type '%v'", methodName, rTargetPointerType.String(), rTargetType.String(), rTargetPointerType.String(), rResultType.String())
 		}
-		rTargetPointer := reflect.New(rTargetType)
-		rTargetPointer.Elem().Set(rTarget)
 		if rMethod.Type.In(1).AssignableTo(rTargetType) {
-			return rMethod.Func.Call([]reflect.Value{rTargetPointer, rValue})[0].Bool(), nil
+			return func(rTarget reflect.Value, rValue reflect.Value) reflect.Value {
+				rTargetPointer := reflect.New(rTargetType)
+				rTargetPointer.Elem().Set(rTarget)
+				return rMethod.Func.Call([]reflect.Value{rTargetPointer, rValue})[0]
+			}, nil
 		}
-		rValuePointer := reflect.New(rTargetType)
-		rValuePointer.Elem().Set(rValue)
-		return rMethod.Func.Call([]reflect.Value{rTargetPointer, rValuePointer})[0].Bool(), nil
+		return func(rTarget reflect.Value, rValue reflect.Value) reflect.Value {
+			rTargetPointer := reflect.New(rTargetType)
+			rTargetPointer.Elem().Set(rTarget)
+			rValuePointer := reflect.New(rTargetType)
+			rValuePointer.Elem().Set(rValue)
+			return rMethod.Func.Call([]reflect.Value{rTargetPointer, rValuePointer})[0]
+		}, nil
 	}
-	return false, fmt.Errorf("type '%v' has no method '%v'", rTargetType.String(), methodName)
+	return nil, fmt.Errorf("type '%v' has no method '%v'", rTargetType.String(), methodName)
 }
 
 func beWithLength(target interface{}) error {
 	rTarget := indirect(target)
-	rTargetType := reflect.TypeOf(target)
 	switch rTarget.Kind() {
 	case reflect.Slice:
 	case reflect.Array:
@@ -116,44 +296,115 @@ func beWithLength(target interface{}) error {
 	case reflect.Map:
 	case reflect.String:
 	default:
-		return fmt.Errorf("argument of type '%v' should has length", rTargetType.String())
+		return fmt.Errorf("argument of type '%T' should has length", target)
 	}
 	return nil
 }
 
 func beEnumerable(target interface{}) error {
 	rTarget := indirect(target)
-	rTargetType := reflect.TypeOf(target)
 	switch rTarget.Kind() {
 	case reflect.Slice:
 	case reflect.Array:
 	case reflect.Map:
 	case reflect.String:
 	default:
-		return fmt.Errorf("argument of type '%v' should be enumerable", rTargetType.String())
+		return fmt.Errorf("argument of type '%T' should be enumerable", target)
 	}
 	return nil
 }
 
-func getElements(target interface{}) []interface{} {
+func beCollection(target interface{}) error {
 	rTarget := indirect(target)
-	length := rTarget.Len()
-	if length == 0 {
-		return nil
+	switch rTarget.Kind() {
+	case reflect.Slice:
+	case reflect.Array:
+	case reflect.Map:
+	default:
+		return fmt.Errorf("argument of type '%T' should be a slice, an array or a map", target)
 	}
-	if rTarget.Kind() == reflect.Map {
-		keys := rTarget.MapKeys()
-		result := make([]interface{}, len(keys))
-		for i, key := range keys {
-			result[i] = rTarget.MapIndex(key).Interface()
+	return nil
+}
+
+func beMap(target interface{}) error {
+	rTarget := indirect(target)
+	if rTarget.Kind() != reflect.Map {
+		return fmt.Errorf("argument of type '%T' should be a map", target)
+	}
+	return nil
+}
+
+// forEachElement calls fn for elements of slice, array, map or string target until fn returns false.
+// Elements are not collected, but every element is passed to fn as interface value, so elements which are not pointers
+// allocate once per element (e.g. 100000 allocations for slice of 100000 ints) and map iteration allocates for key too.
+// Maps are iterated in unspecified order without collecting keys, so indexes of map elements are iteration counts.
+// Strings are iterated by runes with byte offsets as indexes. Key is invalid for targets except maps.
+func forEachElement(target interface{}, fn func(index int, rKey reflect.Value, element interface{}) bool) {
+	rTarget := indirect(target)
+	switch rTarget.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i, length := 0, rTarget.Len(); i < length; i++ {
+			if !fn(i, reflect.Value{}, rTarget.Index(i).Interface()) {
+				return
+			}
+		}
+	case reflect.Map:
+		i := 0
+		rangeMap(rTarget, func(rKey reflect.Value, rElement reflect.Value) bool {
+			i++
+			return fn(i-1, rKey, rElement.Interface())
+		})
+	case reflect.String:
+		for i, element := range rTarget.String() {
+			if !fn(i, reflect.Value{}, element) {
+				return
+			}
 		}
-		return result
 	}
-	result := make([]interface{}, length)
-	for i := 0; i < length; i++ {
-		result[i] = rTarget.Index(i).Interface()
+}
+
+// sortedKeys returns keys of map sorted with comparison of c.
+// Keys of predeclared number and string types without comparators are sorted without reflection calls.
+func (c Configurator) sortedKeys(rMap reflect.Value) []reflect.Value {
+	keys := rMap.MapKeys()
+	less := func(i, j int) bool {
+		return c.lessKey(keys[i], keys[j])
+	}
+	if rKeyType := rMap.Type().Key(); rKeyType.PkgPath() == "" {
+		if !c.hasComparator(rKeyType) {
+			switch getNumberKind(rKeyType.Kind()) {
+			case reflect.Int64:
+				less = func(i, j int) bool {
+					return keys[i].Int() < keys[j].Int()
+				}
+			case reflect.Uint64:
+				less = func(i, j int) bool {
+					return keys[i].Uint() < keys[j].Uint()
+				}
+			case reflect.Float64:
+				less = func(i, j int) bool {
+					return keys[i].Float() < keys[j].Float()
+				}
+			}
+			if rKeyType.Kind() == reflect.String {
+				less = func(i, j int) bool {
+					return keys[i].String() < keys[j].String()
+				}
+			}
+		}
+	}
+	sort.Slice(keys, less)
+	return keys
+}
+
+func (c Configurator) lessKey(rKey1 reflect.Value, rKey2 reflect.Value) bool {
+	key1, key2 := rKey1.Interface(), rKey2.Interface()
+	if reflect.TypeOf(key1) == reflect.TypeOf(key2) {
+		if result, err := c.compare(key1, key2); err == nil {
+			return result < 0
+		}
 	}
-	return result
+	return fmt.Sprintf("%T:%v", key1, key1) < fmt.Sprintf("%T:%v", key2, key2)
 }
 
 func getLength(target interface{}) int {
@@ -169,23 +420,48 @@ func indirect(target interface{}) reflect.Value {
 	return rTarget
 }
 
+// indirectPair dereferences pointers of target and value while they are not nil.
+// Pointers are dereferenced in pairs, so nil pointers are compared with pointers of the same depth.
 func indirectPair(target interface{}, value interface{}) (reflect.Value, reflect.Value) {
 	rTarget := reflect.ValueOf(target)
 	rValue := reflect.ValueOf(value)
-	for rTarget.Kind() == reflect.Ptr && !rTarget.IsNil() && !rValue.IsNil() {
-		rTarget = rTarget.Elem()
-		rValue = rValue.Elem()
+	for {
+		isTargetPointer, isValuePointer := rTarget.Kind() == reflect.Ptr, rValue.Kind() == reflect.Ptr
+		switch {
+		case isTargetPointer && isValuePointer:
+			if rTarget.IsNil() || rValue.IsNil() {
+				return rTarget, rValue
+			}
+			rTarget, rValue = rTarget.Elem(), rValue.Elem()
+		case isTargetPointer && !rTarget.IsNil():
+			rTarget = rTarget.Elem()
+		case isValuePointer && !rValue.IsNil():
+			rValue = rValue.Elem()
+		default:
+			return rTarget, rValue
+		}
 	}
-	return rTarget, rValue
 }
 
 func equal(target interface{}, value interface{}) bool {
+	if target == nil || value == nil {
+		return target == value
+	}
+	if result, ok := equalByRegistry(target, value); ok {
+		return result
+	}
+	if result, ok := compareByRegistry(target, value); ok {
+		return result == 0
+	}
 	rTarget, rValue := indirectPair(target, value)
 	for _, methodName := range EqualMethodNames {
 		if equal, err := callMethodBool(methodName, rTarget, rValue); err == nil {
 			return equal
 		}
 	}
+	if result, ok := compareByCompareMethods(CompareMethodNames, rTarget, rValue); ok {
+		return result == 0
+	}
 	rTargetType := rTarget.Type()
 	if rTargetType.Kind() != reflect.Interface && rTargetType.Comparable() && rTarget.Interface() == rValue.Interface() {
 		return true
@@ -219,6 +495,15 @@ func compareByMethod(lowerMethodName string, rTarget reflect.Value, rValue refle
 	return 0, false
 }
 
+func compareByCompareMethods(compareMethodNames []string, rTarget reflect.Value, rValue reflect.Value) (int, bool) {
+	for _, compareMethodName := range compareMethodNames {
+		if result, err := callMethodInt(compareMethodName, rTarget, rValue); err == nil {
+			return result, true
+		}
+	}
+	return 0, false
+}
+
 func compareByMethods(lowerMethodNames []string, rTarget reflect.Value, rValue reflect.Value) (int, bool) {
 	for _, lowerMethodName := range lowerMethodNames {
 		if result, ok := compareByMethod(lowerMethodName, rTarget, rValue); ok {
@@ -229,7 +514,19 @@ func compareByMethods(lowerMethodNames []string, rTarget reflect.Value, rValue r
 }
 
 func compare(target interface{}, value interface{}) (int, error) {
+	if isNil(target) {
+		return 0, errors.New("argument should not be nil")
+	}
+	if value == nil {
+		return 0, errors.New("value should not be nil")
+	}
+	if result, ok := compareByRegistry(target, value); ok {
+		return result, nil
+	}
 	rTarget, rValue := indirectPair(target, value)
+	if result, ok := compareByCompareMethods(CompareMethodNames, rTarget, rValue); ok {
+		return result, nil
+	}
 	if result, ok := compareByMethods(LowerMethodNames, rTarget, rValue); ok {
 		return result, nil
 	}
@@ -1,6 +1,8 @@
 package configuring
 
 import (
+	"math"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -52,6 +54,11 @@ func TestSetValue(t *testing.T) {
 	if a != value {
 		t.Errorf("expected '%v', was '%v'", value, a)
 	}
+	b := &a
+	setValue(&b, nil)
+	if b != nil {
+		t.Errorf("expected '%v', was '%v'", nil, b)
+	}
 }
 
 func TestConvert(t *testing.T) {
@@ -82,6 +89,36 @@ func TestConvert(t *testing.T) {
 	}
 }
 
+func TestConvert_Lossless(t *testing.T) {
+	type testCase struct {
+		TestCase             string
+		ExpectedErrorMessage string
+		ExpectedResult       interface{}
+		Target               interface{}
+		Value                interface{}
+	}
+	testCases := []testCase{
+		{TestCase: "int to uint8", ExpectedResult: uint8(255), Target: uint8(0), Value: 255},
+		{TestCase: "int to uint8 overflow", ExpectedErrorMessage: "argument '300' of type 'int' overflows type 'uint8'", Target: uint8(0), Value: 300},
+		{TestCase: "negative int to uint", ExpectedErrorMessage: "argument '-1' of type 'int' overflows type 'uint'", Target: uint(0), Value: -1},
+		{TestCase: "uint64 to int64", ExpectedErrorMessage: "argument '18446744073709551615' of type 'uint64' overflows type 'int64'", Target: int64(0), Value: uint64(math.MaxUint64)},
+		{TestCase: "float to int", ExpectedResult: 2, Target: 0, Value: 2.0},
+		{TestCase: "float to int precision", ExpectedErrorMessage: "argument '0.5' of type 'float64' loses precision on conversion to type 'int'", Target: 0, Value: 0.5},
+		{TestCase: "float to int8 overflow", ExpectedErrorMessage: "argument '1000' of type 'float64' overflows type 'int8'", Target: int8(0), Value: 1000.0},
+		{TestCase: "int to float32 precision", ExpectedErrorMessage: "argument '16777217' of type 'int' loses precision on conversion to type 'float32'", Target: float32(0), Value: 16777217},
+		{TestCase: "float64 to float32", ExpectedResult: float32(0.1), Target: float32(0), Value: 0.1},
+		{TestCase: "float64 to float32 overflow", ExpectedErrorMessage: "argument '1e+300' of type 'float64' overflows type 'float32'", Target: float32(0), Value: 1e300},
+		{TestCase: "int to string", ExpectedErrorMessage: "argument '65' of type 'int' should not be converted to type 'string' (conversion produces a rune instead of digits)", Target: "", Value: 65},
+		{TestCase: "bytes to string", ExpectedResult: "A", Target: "", Value: []byte("A")},
+		{TestCase: "duration", ExpectedResult: time.Second, Target: time.Duration(0), Value: 1000000000},
+	}
+	for _, tc := range testCases {
+		if result, err := convert(tc.Target, tc.Value); (err == nil) != (tc.ExpectedErrorMessage == "") || err != nil && err.Error() != tc.ExpectedErrorMessage || result != tc.ExpectedResult {
+			t.Errorf("TestCase '%v': expected '%v', was '%v'", tc.TestCase, []interface{}{tc.ExpectedResult, tc.ExpectedErrorMessage}, []interface{}{result, err})
+		}
+	}
+}
+
 func TestConvertNotNil(t *testing.T) {
 	var result interface{}
 	var err error
@@ -138,15 +175,15 @@ func TestConvertArray(t *testing.T) {
 	var c *t1
 	var d *t2
 	result, err = convertArray(p, []interface{}{&a, nil, &b, int(4)})
-	if err == nil || err.Error() != "invalid element at index '1': argument should not be nil" {
-		t.Errorf("expected '%v', was '%v'", "invalid element at index '1': argument should not be nil", err)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
 	}
-	if result != nil {
-		t.Errorf("expected '%v', was '%v'", []interface{}(nil), result)
+	if len(result) != 4 || result[1] != (*int)(nil) || *result[3].(*int) != 4 {
+		t.Errorf("expected '%v', was '%v'", []interface{}{&a, (*int)(nil), &b, 4}, result)
 	}
-	result, err = convertArray(p, []interface{}{&a, int(2), &b, nil})
-	if err == nil || err.Error() != "invalid element at index '1': argument of type 'int' should be convertible to type '*int'" {
-		t.Errorf("expected '%v', was '%v'", "invalid element at index '1': argument of type 'int' should be convertible to type '*int'", err)
+	result, err = convertArray(p, []interface{}{&a, false, &b, nil})
+	if err == nil || err.Error() != "invalid element at index '1': argument of type 'bool' should be convertible to type 'int'" {
+		t.Errorf("expected '%v', was '%v'", "invalid element at index '1': argument of type 'bool' should be convertible to type 'int'", err)
 	}
 	if result != nil {
 		t.Errorf("expected '%v', was '%v'", []interface{}(nil), result)
@@ -262,6 +299,20 @@ func TestCallMethodBool(t *testing.T) {
 	}
 }
 
+func TestCallMethodInt(t *testing.T) {
+	rTarget, rValue := indirectPair(big.NewInt(1), big.NewInt(3))
+	if result, err := callMethodInt("Cmp", rTarget, rValue); err != nil || result != -1 {
+		t.Errorf("expected '%v', was '%v'", []interface{}{-1, error(nil)}, []interface{}{result, err})
+	}
+	if result, err := callMethodInt("Sign", rTarget, rValue); err == nil || err.Error() != "method 'Sign' of type '*big.Int' should accept argument of type 'big.Int' or '*big.Int' and should return result of type 'int'" {
+		t.Errorf("expected '%v', was '%v'", []interface{}{0, "method 'Sign' of type '*big.Int' should accept argument of type 'big.Int' or '*big.Int' and should return result of type 'int'"}, []interface{}{result, err})
+	}
+	rTarget, rValue = indirectPair(testType{}, testType{})
+	if result, err := callMethodInt("Foo", rTarget, rValue); err == nil || err.Error() != "method 'Foo' of type 'configuring.testType' should accept argument of type 'configuring.testType' or '*configuring.testType' and should return result of type 'int'" {
+		t.Errorf("expected '%v', was '%v'", []interface{}{0, "method 'Foo' of type 'configuring.testType' should accept argument of type 'configuring.testType' or '*configuring.testType' and should return result of type 'int'"}, []interface{}{result, err})
+	}
+}
+
 func TestIndirectPair(t *testing.T) {
 	i1, i2 := 1, 2
 	pi1, pi2 := &i1, &i2
@@ -278,6 +329,15 @@ func TestIndirectPair(t *testing.T) {
 	if ri1, ri2 := indirectPair(new(*int), new(*int)); ri1.Kind() != reflect.Ptr && ri2.Kind() != reflect.Ptr {
 		t.Errorf("expected '%v', was '%v'", reflect.Ptr, ri1.Kind())
 	}
+	if ri1, ri2 := indirectPair(ppi1, i2); ri1.Kind() != reflect.Int || ri2.Kind() != reflect.Int {
+		t.Errorf("expected '%v', was '%v' and '%v'", reflect.Int, ri1.Kind(), ri2.Kind())
+	}
+	if ri1, ri2 := indirectPair(i1, pi2); ri1.Kind() != reflect.Int || ri2.Kind() != reflect.Int {
+		t.Errorf("expected '%v', was '%v' and '%v'", reflect.Int, ri1.Kind(), ri2.Kind())
+	}
+	if ri1, ri2 := indirectPair((*int)(nil), i2); ri1.Kind() != reflect.Ptr || ri2.Kind() != reflect.Int {
+		t.Errorf("expected '%v', was '%v'", reflect.Ptr, ri1.Kind())
+	}
 }
 
 func TestEqual(t *testing.T) {
@@ -301,9 +361,25 @@ func TestEqual(t *testing.T) {
 	if result := equal(&pt1, &pt2); !result {
 		t.Errorf("expected '%v', was '%v'", true, result)
 	}
+	if result := equal(big.NewFloat(2), new(big.Float).SetPrec(200).SetInt64(2)); !result {
+		t.Errorf("expected '%v', was '%v'", true, result)
+	}
 	if result := equal(func() {}, func() {}); result {
 		t.Errorf("expected '%v', was '%v'", false, result)
 	}
+	i1 := 1
+	if result := equal(&i1, 1); !result {
+		t.Errorf("expected '%v', was '%v'", true, result)
+	}
+	if result := equal(nil, nil); !result {
+		t.Errorf("expected '%v', was '%v'", true, result)
+	}
+	if result := equal(nil, 1); result {
+		t.Errorf("expected '%v', was '%v'", false, result)
+	}
+	if result := equal((*int)(nil), (*int)(nil)); !result {
+		t.Errorf("expected '%v', was '%v'", true, result)
+	}
 }
 
 func TestHasEqual(t *testing.T) {
@@ -379,6 +455,12 @@ func (i1 greaterInt) Greater(i2 greaterInt) bool {
 
 type lowerInt int
 
+type cmpInt int
+
+func (i1 *cmpInt) Cmp(i2 *cmpInt) int {
+	return int(*i1 - *i2)
+}
+
 func (i1 lowerInt) Lower(i2 lowerInt) bool {
 	return i1 < i2
 }
@@ -404,7 +486,38 @@ func TestCompare(t *testing.T) {
 	type tuint16 uint16
 	type tuint32 uint32
 	type tuint64 uint64
+	i1, i2 := 1, 2
 	testCases := []testCase{
+		{
+			TestCase:             "nil: error",
+			ExpectedErrorMessage: "argument should not be nil",
+			Target:               nil,
+			Value:                1,
+		},
+		{
+			TestCase:             "nil pointer: error",
+			ExpectedErrorMessage: "argument should not be nil",
+			Target:               (*int)(nil),
+			Value:                &i1,
+		},
+		{
+			TestCase:             "nil value: error",
+			ExpectedErrorMessage: "value should not be nil",
+			Target:               1,
+			Value:                nil,
+		},
+		{
+			TestCase:       "pointer and int: -1",
+			ExpectedResult: -1,
+			Target:         &i1,
+			Value:          i2,
+		},
+		{
+			TestCase:       "int and pointer: 1",
+			ExpectedResult: 1,
+			Target:         i2,
+			Value:          &i1,
+		},
 		{
 			TestCase:             "bool: error",
 			ExpectedErrorMessage: "argument of type 'bool' can not be lower than or greater than value of type 'bool'",
@@ -447,6 +560,42 @@ func TestCompare(t *testing.T) {
 			Target:         greaterInt(2),
 			Value:          greaterInt(1),
 		},
+		{
+			TestCase:       "cmpInt: -1",
+			ExpectedResult: -1,
+			Target:         cmpInt(1),
+			Value:          cmpInt(5),
+		},
+		{
+			TestCase:       "cmpInt: 0",
+			ExpectedResult: 0,
+			Target:         cmpInt(5),
+			Value:          cmpInt(5),
+		},
+		{
+			TestCase:       "cmpInt: 1",
+			ExpectedResult: 1,
+			Target:         cmpInt(5),
+			Value:          cmpInt(1),
+		},
+		{
+			TestCase:       "big.Int: -1",
+			ExpectedResult: -1,
+			Target:         big.NewInt(1),
+			Value:          big.NewInt(2),
+		},
+		{
+			TestCase:       "big.Int: 1",
+			ExpectedResult: 1,
+			Target:         *big.NewInt(2),
+			Value:          *big.NewInt(1),
+		},
+		{
+			TestCase:       "big.Float: 0",
+			ExpectedResult: 0,
+			Target:         big.NewFloat(1.5),
+			Value:          new(big.Float).SetPrec(200).SetFloat64(1.5),
+		},
 		{
 			TestCase:       "float32: -1",
 			ExpectedResult: -1,
@@ -700,3 +849,47 @@ func TestCompare(t *testing.T) {
 		}
 	}
 }
+
+func TestForEachElement(t *testing.T) {
+	var result []interface{}
+	collect := func(index int, rKey reflect.Value, element interface{}) bool {
+		if rKey.IsValid() {
+			result = append(result, rKey.Interface())
+		} else {
+			result = append(result, index)
+		}
+		result = append(result, element)
+		return true
+	}
+	forEachElement([]int{5, 6}, collect)
+	if !reflect.DeepEqual(result, []interface{}{0, 5, 1, 6}) {
+		t.Errorf("expected '%v', was '%v'", []interface{}{0, 5, 1, 6}, result)
+	}
+	elements := map[interface{}]interface{}{}
+	var indexes []int
+	collectMap := func(index int, rKey reflect.Value, element interface{}) bool {
+		elements[rKey.Interface()] = element
+		indexes = append(indexes, index)
+		return true
+	}
+	forEachElement(&map[interface{}]int{"b": 1, 2: 2, 1: 3}, collectMap)
+	if expected := map[interface{}]interface{}{"b": 1, 2: 2, 1: 3}; !reflect.DeepEqual(elements, expected) {
+		t.Errorf("expected '%v', was '%v'", expected, elements)
+	}
+	if !reflect.DeepEqual(indexes, []int{0, 1, 2}) {
+		t.Errorf("expected '%v', was '%v'", []int{0, 1, 2}, indexes)
+	}
+	result = nil
+	forEachElement("añb", collect)
+	if !reflect.DeepEqual(result, []interface{}{0, 'a', 1, 'ñ', 3, 'b'}) {
+		t.Errorf("expected '%v', was '%v'", []interface{}{0, 'a', 1, 'ñ', 3, 'b'}, result)
+	}
+	result = nil
+	forEachElement([3]int{1, 2, 3}, func(index int, rKey reflect.Value, element interface{}) bool {
+		result = append(result, element)
+		return index < 1
+	})
+	if !reflect.DeepEqual(result, []interface{}{1, 2}) {
+		t.Errorf("expected '%v', was '%v'", []interface{}{1, 2}, result)
+	}
+}
@@ -0,0 +1,418 @@
+package configuring
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RulesTagName is a struct tag name of rules (e.g. `configuring:"min=1s,max=1m,default=5s"`).
+const RulesTagName = "configuring"
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// Rule is a rule of rule language.
+// Rules with values have operator (e.g. 'min=1s' or 'len>=1'), group rules have nested rules (e.g. 'each(min=1)')
+// and flag rules have only name (e.g. 'secret').
+type Rule struct {
+	Name     string
+	Operator string
+	Values   []string
+	Rules    Rules
+}
+
+// Rules is a list of rules of rule language.
+// Supported rules:
+// min=value, max=value, default=value - min, max and default values;
+// oneof=value1 value2, noneof=value1 value2 - allowed and disallowed values separated by spaces;
+// len=value, len>=value, len<=value - length of target;
+// each(rules) - rules of target elements;
+// keys(rules) - rules of target map keys;
+// secret - secret configuration.
+// Values may be quoted with single quotes (e.g. 'a, b') and are parsed as literals of target type.
+type Rules []Rule
+
+// RuleSyntaxError is an error of rule language syntax.
+type RuleSyntaxError struct {
+	// Column is a position of invalid character started from 1.
+	Column  int
+	Message string
+}
+
+func (e *RuleSyntaxError) Error() string {
+	return fmt.Sprintf("rule syntax error at column %v: %v", e.Column, e.Message)
+}
+
+// ParseRules parses rules of rule language (e.g. "min=1s,max=1m,oneof=1s 5s 1m,default=5s").
+func ParseRules(text string) (Rules, error) {
+	parser := ruleParser{text: []rune(text)}
+	rules, err := parser.parseRules(0)
+	if err != nil {
+		return nil, err
+	}
+	if parser.position < len(parser.text) {
+		return nil, parser.errorf("unexpected character '%v'", string(parser.text[parser.position]))
+	}
+	return rules, nil
+}
+
+// MustParseRules parses rules of rule language and panics on syntax error.
+func MustParseRules(text string) Rules {
+	rules, err := ParseRules(text)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// String returns rules in rule language. Returned text is parsed to the same rules.
+func (r Rules) String() string {
+	var builder strings.Builder
+	for i, rule := range r {
+		if i > 0 {
+			_, _ = builder.WriteString(",")
+		}
+		_, _ = builder.WriteString(rule.String())
+	}
+	return builder.String()
+}
+
+// String returns rule in rule language.
+func (r Rule) String() string {
+	if r.Operator == "" && r.Values == nil && r.Rules == nil {
+		return r.Name
+	}
+	if r.Operator == "" {
+		return r.Name + "(" + r.Rules.String() + ")"
+	}
+	values := make([]string, len(r.Values))
+	for i, value := range r.Values {
+		values[i] = quoteRuleValue(value)
+	}
+	return r.Name + r.Operator + strings.Join(values, " ")
+}
+
+func quoteRuleValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " ,()'\\") {
+		return value
+	}
+	var builder strings.Builder
+	_, _ = builder.WriteString("'")
+	for _, r := range value {
+		if r == '\'' || r == '\\' {
+			_, _ = builder.WriteString("\\")
+		}
+		_, _ = builder.WriteRune(r)
+	}
+	_, _ = builder.WriteString("'")
+	return builder.String()
+}
+
+type ruleParser struct {
+	text     []rune
+	position int
+}
+
+func (p *ruleParser) errorf(format string, args ...interface{}) error {
+	return &RuleSyntaxError{Column: p.position + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *ruleParser) peek() rune {
+	if p.position < len(p.text) {
+		return p.text[p.position]
+	}
+	return 0
+}
+
+func (p *ruleParser) skipSpaces() {
+	for p.peek() == ' ' {
+		p.position++
+	}
+}
+
+func (p *ruleParser) parseRules(depth int) (Rules, error) {
+	var rules Rules
+	for {
+		p.skipSpaces()
+		rule, err := p.parseRule(depth)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+		p.skipSpaces()
+		if p.peek() != ',' {
+			return rules, nil
+		}
+		p.position++
+	}
+}
+
+func (p *ruleParser) parseRule(depth int) (Rule, error) {
+	start := p.position
+	for r := p.peek(); r >= 'a' && r <= 'z'; r = p.peek() {
+		p.position++
+	}
+	rule := Rule{Name: string(p.text[start:p.position])}
+	if rule.Name == "" {
+		if p.position == len(p.text) {
+			return rule, p.errorf("rule name expected")
+		}
+		return rule, p.errorf("rule name expected, found '%v'", string(p.peek()))
+	}
+	switch rule.Name {
+	case "min", "max", "default", "oneof", "noneof", "len", "each", "keys", "secret":
+	default:
+		p.position = start
+		return rule, p.errorf("unknown rule '%v'", rule.Name)
+	}
+	switch p.peek() {
+	case '(':
+		if rule.Name != "each" && rule.Name != "keys" {
+			return rule, p.errorf("rule '%v' should not have nested rules", rule.Name)
+		}
+		p.position++
+		rules, err := p.parseRules(depth + 1)
+		if err != nil {
+			return rule, err
+		}
+		if p.peek() != ')' {
+			return rule, p.errorf("')' expected")
+		}
+		p.position++
+		rule.Rules = rules
+		return rule, nil
+	case '=':
+		rule.Operator = "="
+	case '>', '<':
+		if p.position+1 >= len(p.text) || p.text[p.position+1] != '=' {
+			return rule, p.errorf("operator '%v=' expected", string(p.peek()))
+		}
+		rule.Operator = string(p.text[p.position : p.position+2])
+	default:
+		if rule.Name != "secret" {
+			return rule, p.errorf("rule '%v' should have operator", rule.Name)
+		}
+		return rule, nil
+	}
+	operatorPosition := p.position
+	p.position += len(rule.Operator)
+	switch rule.Name {
+	case "min", "max", "default", "oneof", "noneof":
+		if rule.Operator != "=" {
+			p.position = operatorPosition
+			return rule, p.errorf("rule '%v' should have operator '='", rule.Name)
+		}
+	case "len":
+	default:
+		p.position = operatorPosition
+		return rule, p.errorf("rule '%v' should not have operator", rule.Name)
+	}
+	for {
+		p.skipSpaces()
+		if r := p.peek(); r == 0 || r == ',' || r == ')' {
+			break
+		}
+		if len(rule.Values) == 1 && rule.Name != "oneof" && rule.Name != "noneof" {
+			return rule, p.errorf("rule '%v' should have single value", rule.Name)
+		}
+		value, err := p.parseValue(depth)
+		if err != nil {
+			return rule, err
+		}
+		rule.Values = append(rule.Values, value)
+	}
+	if len(rule.Values) == 0 {
+		return rule, p.errorf("rule '%v' should have value", rule.Name)
+	}
+	return rule, nil
+}
+
+func (p *ruleParser) parseValue(depth int) (string, error) {
+	if p.peek() == '\'' {
+		p.position++
+		var builder strings.Builder
+		for {
+			r := p.peek()
+			switch r {
+			case 0:
+				return "", p.errorf("closing quote expected")
+			case '\'':
+				p.position++
+				return builder.String(), nil
+			case '\\':
+				p.position++
+				if r = p.peek(); r != '\'' && r != '\\' {
+					return "", p.errorf("invalid escape character")
+				}
+			}
+			_, _ = builder.WriteRune(r)
+			p.position++
+		}
+	}
+	start := p.position
+	for r := p.peek(); r != 0 && r != ' ' && r != ',' && r != ')'; r = p.peek() {
+		if r == '\'' || r == '(' {
+			return "", p.errorf("unexpected character '%v'", string(r))
+		}
+		p.position++
+	}
+	if p.peek() == ')' && depth == 0 {
+		return "", p.errorf("unexpected character ')'")
+	}
+	return string(p.text[start:p.position]), nil
+}
+
+// ruleLiteral is a value of rule language parsed on conversion to target type.
+type ruleLiteral string
+
+// WithRules provides rules of rule language. Rule values are parsed as literals of target type:
+// with registered converters (see RegisterConverter), 'UnmarshalText' method for text types and with 'strconv' for other types.
+func (c Configurator) WithRules(rules Rules) Configurator {
+	for _, rule := range rules {
+		switch rule.Name {
+		case "min":
+			c = c.WithMin(ruleLiteral(rule.Values[0]))
+		case "max":
+			c = c.WithMax(ruleLiteral(rule.Values[0]))
+		case "default":
+			c = c.WithDefault(ruleLiteral(rule.Values[0]))
+		case "oneof":
+			c = c.WithAllowed(ruleLiterals(rule.Values)...)
+		case "noneof":
+			c = c.WithDisallowed(ruleLiterals(rule.Values)...)
+		case "len":
+			lengthValidator := NewConfigurator()
+			if rule.Operator != "<=" {
+				lengthValidator = lengthValidator.WithMin(ruleLiteral(rule.Values[0]))
+			}
+			if rule.Operator != ">=" {
+				lengthValidator = lengthValidator.WithMax(ruleLiteral(rule.Values[0]))
+			}
+			c = c.WithLengthValidators(lengthValidator)
+		case "each":
+			c = c.WithElementValidators(NewConfigurator().WithRules(rule.Rules))
+		case "keys":
+			c = c.WithKeyValidators(NewConfigurator().WithRules(rule.Rules))
+		case "secret":
+			c = c.Secret()
+		}
+	}
+	return c
+}
+
+func ruleLiterals(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = ruleLiteral(value)
+	}
+	return result
+}
+
+func parseLiteral(rTargetType reflect.Type, literal string) (interface{}, error) {
+	rValue := reflect.New(rTargetType).Elem()
+	if err := parseLiteralTo(rValue, literal); err != nil {
+		return nil, fmt.Errorf("literal '%v' should be parsed as value of type '%v': %v", literal, rTargetType.String(), err)
+	}
+	return rValue.Interface(), nil
+}
+
+func parseLiteralTo(rValue reflect.Value, literal string) error {
+	rType := rValue.Type()
+	if rResult, found, err := convertString(rType, literal); found {
+		if err != nil {
+			return err
+		}
+		rValue.Set(rResult)
+		return nil
+	}
+	switch rType.Kind() {
+	case reflect.Bool:
+		value, err := strconv.ParseBool(literal)
+		if err != nil {
+			return err
+		}
+		rValue.SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(literal, 0, rType.Bits())
+		if err != nil {
+			return err
+		}
+		rValue.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value, err := strconv.ParseUint(literal, 0, rType.Bits())
+		if err != nil {
+			return err
+		}
+		rValue.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(literal, rType.Bits())
+		if err != nil {
+			return err
+		}
+		rValue.SetFloat(value)
+	case reflect.String:
+		rValue.SetString(literal)
+	case reflect.Ptr:
+		rElement := reflect.New(rType.Elem())
+		if err := parseLiteralTo(rElement.Elem(), literal); err != nil {
+			return err
+		}
+		rValue.Set(rElement)
+	default:
+		return fmt.Errorf("type '%v' has no literals", rType.String())
+	}
+	return nil
+}
+
+// ConfigureStruct configures fields of struct target with rules of struct tags
+// (e.g. `configuring:"min=1s,max=1m,default=5s"`).
+// Fields are named as Go field paths (e.g. 'Server.Port') prefixed with name of c.
+// Struct fields without rules are configured recursively.
+func (c Configurator) ConfigureStruct(targetPointer interface{}) error {
+	if err := beConfigurable(targetPointer); err != nil {
+		return c.wrapError("configuration", fmt.Errorf("target value is not configurable: %v", err))
+	}
+	rTarget := reflect.ValueOf(targetPointer).Elem()
+	if rTarget.Kind() != reflect.Struct {
+		return c.wrapError("configuration", fmt.Errorf("target value is not configurable: argument of type '%v' should be a pointer to a struct", reflect.TypeOf(targetPointer).String()))
+	}
+	return c.configureStruct(rTarget, c.name)
+}
+
+func (c Configurator) configureStruct(rStruct reflect.Value, path string) error {
+	rStructType := rStruct.Type()
+	for i := 0; i < rStructType.NumField(); i++ {
+		rField := rStructType.Field(i)
+		if rField.PkgPath != "" {
+			continue
+		}
+		fieldPath := rField.Name
+		if path != "" {
+			fieldPath = path + "." + rField.Name
+		}
+		tag, hasTag := rField.Tag.Lookup(RulesTagName)
+		if !hasTag {
+			rFieldValue := rStruct.Field(i)
+			for rFieldValue.Kind() == reflect.Ptr && !rFieldValue.IsNil() {
+				rFieldValue = rFieldValue.Elem()
+			}
+			if rFieldValue.Kind() == reflect.Struct && !isTextType(rFieldValue.Type()) {
+				if err := c.configureStruct(rFieldValue, fieldPath); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		configurator := c.base().WithName(fieldPath)
+		rules, err := ParseRules(tag)
+		if err != nil {
+			return configurator.wrapError("configuration", fmt.Errorf("invalid rules: %v", err))
+		}
+		if err := configurator.WithRules(rules).Configure(rStruct.Field(i).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package configuring
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("min=1s, max=1m,oneof=1s 5s 'a, b' '',len>=1,each(min=1,each(len=2)),default=5s,secret")
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	expected := Rules{
+		{Name: "min", Operator: "=", Values: []string{"1s"}},
+		{Name: "max", Operator: "=", Values: []string{"1m"}},
+		{Name: "oneof", Operator: "=", Values: []string{"1s", "5s", "a, b", ""}},
+		{Name: "len", Operator: ">=", Values: []string{"1"}},
+		{Name: "each", Rules: Rules{
+			{Name: "min", Operator: "=", Values: []string{"1"}},
+			{Name: "each", Rules: Rules{{Name: "len", Operator: "=", Values: []string{"2"}}}},
+		}},
+		{Name: "default", Operator: "=", Values: []string{"5s"}},
+		{Name: "secret"},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected '%v', was '%v'", expected, rules)
+	}
+	text := rules.String()
+	if text != "min=1s,max=1m,oneof=1s 5s 'a, b' '',len>=1,each(min=1,each(len=2)),default=5s,secret" {
+		t.Errorf("expected '%v', was '%v'", "min=1s,max=1m,oneof=1s 5s 'a, b' '',len>=1,each(min=1,each(len=2)),default=5s,secret", text)
+	}
+	if parsed, err := ParseRules(text); err != nil || !reflect.DeepEqual(parsed, rules) {
+		t.Errorf("expected '%v', was '%v'", []interface{}{rules, error(nil)}, []interface{}{parsed, err})
+	}
+	if text := (Rules{{Name: "oneof", Operator: "=", Values: []string{`it's`, `a\b`, "(x)"}}}).String(); text != `oneof='it\'s' 'a\\b' '(x)'` {
+		t.Errorf("expected '%v', was '%v'", `oneof='it\'s' 'a\\b' '(x)'`, text)
+	}
+	type testCase struct {
+		Text                 string
+		ExpectedErrorMessage string
+	}
+	testCases := []testCase{
+		{Text: "", ExpectedErrorMessage: "rule syntax error at column 1: rule name expected"},
+		{Text: "min=1,", ExpectedErrorMessage: "rule syntax error at column 7: rule name expected"},
+		{Text: "min=1,foo=2", ExpectedErrorMessage: "rule syntax error at column 7: unknown rule 'foo'"},
+		{Text: "min", ExpectedErrorMessage: "rule syntax error at column 4: rule 'min' should have operator"},
+		{Text: "min>=1", ExpectedErrorMessage: "rule syntax error at column 4: rule 'min' should have operator '='"},
+		{Text: "len>1", ExpectedErrorMessage: "rule syntax error at column 4: operator '>=' expected"},
+		{Text: "max=", ExpectedErrorMessage: "rule syntax error at column 5: rule 'max' should have value"},
+		{Text: "max=1 2", ExpectedErrorMessage: "rule syntax error at column 7: rule 'max' should have single value"},
+		{Text: "secret=1", ExpectedErrorMessage: "rule syntax error at column 7: rule 'secret' should not have operator"},
+		{Text: "min(max=1)", ExpectedErrorMessage: "rule syntax error at column 4: rule 'min' should not have nested rules"},
+		{Text: "each(min=1", ExpectedErrorMessage: "rule syntax error at column 11: ')' expected"},
+		{Text: "min=1)", ExpectedErrorMessage: "rule syntax error at column 6: unexpected character ')'"},
+		{Text: "oneof='a", ExpectedErrorMessage: "rule syntax error at column 9: closing quote expected"},
+		{Text: `oneof='\a'`, ExpectedErrorMessage: "rule syntax error at column 9: invalid escape character"},
+		{Text: "oneof=a'b'", ExpectedErrorMessage: "rule syntax error at column 8: unexpected character '''"},
+	}
+	for _, tc := range testCases {
+		if _, err := ParseRules(tc.Text); err == nil || err.Error() != tc.ExpectedErrorMessage {
+			t.Errorf("TestCase '%v': expected '%v', was '%v'", tc.Text, tc.ExpectedErrorMessage, err)
+		}
+	}
+}
+
+func TestConfigurator_WithRules(t *testing.T) {
+	calls := 0
+	err := NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		if message := fmt.Sprintf(format, args...); message != "configuration: min: '1s' max: '1m0s' allowed: ['1s','5s','1m0s'] default: '5s' input: '0s' output: '5s'" {
+			t.Errorf("expected '%v', was '%v'", "configuration: min: '1s' max: '1m0s' allowed: ['1s','5s','1m0s'] default: '5s' input: '0s' output: '5s'", message)
+		}
+		calls = calls + 1
+	}).WithRules(MustParseRules("min=1s,max=1m,oneof=1s 5s 1m,default=5s")).Configure(new(time.Duration))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	address := net.IPv4(127, 0, 0, 2)
+	err = NewConfigurator().WithRules(MustParseRules("noneof=0.0.0.0,default=127.0.0.1")).Configure(&address)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithRules(MustParseRules("len>=1,each(min=1,max=3)")).Validate([]uint8{1, 2, 3})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithRules(MustParseRules("keys(oneof=a b)")).Validate(map[string]int{"a": 1, "c": 2})
+	if err == nil || err.Error() != "validation error: argument key 'c' should be validated with validator at index '0': validation error: argument should be in allowed values ['a','b']" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument key 'c' should be validated with validator at index '0': validation error: argument should be in allowed values ['a','b']", err)
+	}
+	err = NewConfigurator().WithRules(MustParseRules("len<=2")).Validate([]uint8{1, 2, 3})
+	if err == nil || err.Error() != "validation error: argument length should be validated with validator at index '0': validation error: argument should be lower than or equal to '2'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument length should be validated with validator at index '0': validation error: argument should be lower than or equal to '2'", err)
+	}
+	err = NewConfigurator().WithRules(MustParseRules("max=256")).Validate(uint8(1))
+	if err == nil || err.Error() != "validation error: invalid max value: literal '256' should be parsed as value of type 'uint8': strconv.ParseUint: parsing \"256\": value out of range" {
+		t.Errorf("expected '%v', was '%v'", "validation error: invalid max value: literal '256' should be parsed as value of type 'uint8': strconv.ParseUint: parsing \"256\": value out of range", err)
+	}
+	err = NewConfigurator().WithRules(MustParseRules("min=1")).Validate(struct{}{})
+	if err == nil || err.Error() != "validation error: invalid min value: literal '1' should be parsed as value of type 'struct {}': type 'struct {}' has no literals" {
+		t.Errorf("expected '%v', was '%v'", "validation error: invalid min value: literal '1' should be parsed as value of type 'struct {}': type 'struct {}' has no literals", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected '%v', was '%v'", 1, calls)
+	}
+}
+
+func TestConfigurator_ConfigureStruct(t *testing.T) {
+	type Server struct {
+		Port    int           `configuring:"min=1,max=65535,default=8080"`
+		Timeout time.Duration `configuring:"min=1s,default=5s"`
+		Level   *string       `configuring:"oneof=debug info"`
+	}
+	type Config struct {
+		Server *Server
+		Hosts  []string `configuring:"len>=1,each(noneof='')"`
+	}
+	level := "info"
+	config := Config{Server: &Server{Level: &level}, Hosts: []string{"localhost"}}
+	var messages []string
+	err := NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}).ConfigureStruct(&config)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if config.Server.Port != 8080 || config.Server.Timeout != 5*time.Second {
+		t.Errorf("expected '%v', was '%v'", Server{Port: 8080, Timeout: 5 * time.Second, Level: &level}, *config.Server)
+	}
+	if expected := []string{
+		"configuration of 'Server.Port': min: '1' max: '65535' default: '8080' input: '0' output: '8080'",
+		"configuration of 'Server.Timeout': min: '1s' default: '5s' input: '0s' output: '5s'",
+	}; len(messages) != 4 || !reflect.DeepEqual(messages[:2], expected) {
+		t.Errorf("expected '%v', was '%v'", expected, messages)
+	}
+	config.Hosts = []string{""}
+	err = NewConfigurator().ConfigureStruct(&config)
+	if err == nil || err.Error() != "configuration of 'Hosts' error: target value error: argument element at index '0' should be validated with validator at index '0': validation error: argument should not be in disallowed values ['']" {
+		t.Errorf("expected '%v', was '%v'", "configuration of 'Hosts' error: target value error: argument element at index '0' should be validated with validator at index '0': validation error: argument should not be in disallowed values ['']", err)
+	}
+	type Invalid struct {
+		Value int `configuring:"min=1,"`
+	}
+	err = NewConfigurator().WithName("app").ConfigureStruct(&Invalid{})
+	if err == nil || err.Error() != "configuration of 'app.Value' error: invalid rules: rule syntax error at column 7: rule name expected" {
+		t.Errorf("expected '%v', was '%v'", "configuration of 'app.Value' error: invalid rules: rule syntax error at column 7: rule name expected", err)
+	}
+	err = NewConfigurator().ConfigureStruct(new(int))
+	if err == nil || err.Error() != "configuration error: target value is not configurable: argument of type '*int' should be a pointer to a struct" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: target value is not configurable: argument of type '*int' should be a pointer to a struct", err)
+	}
+	schema, err := StructSchema(Server{})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := toJSON(t, schema["properties"].(Schema)["Port"]); result != `{"default":8080,"maximum":65535,"minimum":1,"title":"Port","type":"integer"}` {
+		t.Errorf("expected '%v', was '%v'", `{"default":8080,"maximum":65535,"minimum":1,"title":"Port","type":"integer"}`, result)
+	}
+}
@@ -0,0 +1,332 @@
+package configuring
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// literalTypeName is type name of values of rule language in rule sets (see Configurator.WithRules).
+const literalTypeName = "literal"
+
+var typeRegistry = struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+}{
+	types: map[string]reflect.Type{},
+}
+
+func init() {
+	for _, value := range []interface{}{
+		false, "", int(0), int8(0), int16(0), int32(0), int64(0), uint(0), uint8(0), uint16(0), uint32(0), uint64(0), uintptr(0),
+		float32(0), float64(0), time.Duration(0),
+	} {
+		RegisterType(value)
+	}
+}
+
+// RegisterType registers type of value by name of type (e.g. 'time.Duration') for serialization of rule sets.
+// Predeclared types, 'time.Duration', types of registered converters and pointers to registered types are registered by default.
+func RegisterType(value interface{}) {
+	rType := reflect.TypeOf(value)
+	if rType == nil {
+		panic(fmt.Errorf("invalid type of value '%v' (value should not be nil)", value))
+	}
+	typeRegistry.Lock()
+	defer typeRegistry.Unlock()
+	typeRegistry.types[rType.String()] = rType
+}
+
+func findType(name string) (reflect.Type, bool) {
+	typeRegistry.RLock()
+	rType, found := typeRegistry.types[name]
+	typeRegistry.RUnlock()
+	if found {
+		return rType, true
+	}
+	converterRegistry.RLock()
+	for rConvertedType := range converterRegistry.convertFns {
+		if rConvertedType.String() == name {
+			rType, found = rConvertedType, true
+		}
+	}
+	converterRegistry.RUnlock()
+	if found {
+		return rType, true
+	}
+	if strings.HasPrefix(name, "*") {
+		if rType, found := findType(name[1:]); found {
+			return reflect.PtrTo(rType), true
+		}
+	}
+	return nil, false
+}
+
+// ruleSet is serialized rule set of configurator.
+type ruleSet struct {
+	Name           string       `json:"name,omitempty"`
+	Scope          string       `json:"scope,omitempty"`
+	Min            *typedValue  `json:"min,omitempty"`
+	Max            *typedValue  `json:"max,omitempty"`
+	Allowed        []typedValue `json:"allowed,omitempty"`
+	Disallowed     []typedValue `json:"disallowed,omitempty"`
+	Default        *typedValue  `json:"default,omitempty"`
+	Fallbacks      []typedValue `json:"fallbacks,omitempty"`
+	Validators     []string     `json:"validators,omitempty"`
+	Secret         bool         `json:"secret,omitempty"`
+	LogChangesOnly bool         `json:"log_changes_only,omitempty"`
+	LogValueFormat string       `json:"log_value_format,omitempty"`
+}
+
+// typedValue is serialized value with name of type. Values without type are decoded as JSON values.
+type typedValue struct {
+	Type  string          `json:"type,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MarshalJSON serializes rule set of configurator: name, scope, min, max, allowed, disallowed, default and fallback values,
+// named validators (see NamedValidator), secret and log settings.
+// Values are serialized with names of their types (see RegisterType), e.g. '{"type":"time.Duration","value":"5s"}'.
+// Values of types with converters or 'UnmarshalText' method are serialized as text.
+// Logger, context, locale and parallelism are not serialized, other rules can not be serialized.
+func (c Configurator) MarshalJSON() ([]byte, error) {
+	if err := c.beSerializable(); err != nil {
+		return nil, c.wrapError("serialization", err)
+	}
+	set := ruleSet{
+		Name:           c.name,
+		Scope:          c.scope,
+		Secret:         c.isSecret,
+		LogChangesOnly: c.logChangesOnly,
+		LogValueFormat: c.logValueFormat,
+	}
+	var err error
+	if set.Min, err = encodeNotNil(c.minValue); err != nil {
+		return nil, c.wrapError("serialization", fmt.Errorf("invalid min value: %v", err))
+	}
+	if set.Max, err = encodeNotNil(c.maxValue); err != nil {
+		return nil, c.wrapError("serialization", fmt.Errorf("invalid max value: %v", err))
+	}
+	if set.Allowed, err = encodeArray(c.allowedValues); err != nil {
+		return nil, c.wrapError("serialization", fmt.Errorf("invalid allowed values: %v", err))
+	}
+	if set.Disallowed, err = encodeArray(c.disallowedValues); err != nil {
+		return nil, c.wrapError("serialization", fmt.Errorf("invalid disallowed values: %v", err))
+	}
+	if set.Default, err = encodeNotNil(c.defaultValue); err != nil {
+		return nil, c.wrapError("serialization", fmt.Errorf("invalid default value: %v", err))
+	}
+	if set.Fallbacks, err = encodeArray(c.fallbackValues); err != nil {
+		return nil, c.wrapError("serialization", fmt.Errorf("invalid fallback values: %v", err))
+	}
+	for _, validator := range c.targetValidators {
+		set.Validators = append(set.Validators, string(validator.(namedValidator)))
+	}
+	return json.Marshal(set)
+}
+
+// UnmarshalJSON provides rule set serialized with MarshalJSON to configurator.
+// Serializable rules, validators, secret and log settings of configurator are replaced with ones of rule set
+// (missing ones are removed), other rules of configurator are kept.
+// Values with type 'literal' are literals of rule language and values without type are JSON values converted on configuration.
+func (c *Configurator) UnmarshalJSON(data []byte) error {
+	var set ruleSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return err
+	}
+	result := c.WithName(set.Name)
+	result.scope = set.Scope
+	var err error
+	if result.minValue, err = decodeNotNil(set.Min); err != nil {
+		return result.wrapError("deserialization", fmt.Errorf("invalid min value: %v", err))
+	}
+	if result.maxValue, err = decodeNotNil(set.Max); err != nil {
+		return result.wrapError("deserialization", fmt.Errorf("invalid max value: %v", err))
+	}
+	if result.allowedValues, err = decodeArray(set.Allowed); err != nil {
+		return result.wrapError("deserialization", fmt.Errorf("invalid allowed values: %v", err))
+	}
+	if result.disallowedValues, err = decodeArray(set.Disallowed); err != nil {
+		return result.wrapError("deserialization", fmt.Errorf("invalid disallowed values: %v", err))
+	}
+	result.defaultValue, result.defaultFn = nil, nil
+	if set.Default != nil {
+		defaultValue, err := decodeNotNil(set.Default)
+		if err != nil {
+			return result.wrapError("deserialization", fmt.Errorf("invalid default value: %v", err))
+		}
+		result = result.WithDefault(defaultValue)
+	}
+	if result.fallbackValues, err = decodeArray(set.Fallbacks); err != nil {
+		return result.wrapError("deserialization", fmt.Errorf("invalid fallback values: %v", err))
+	}
+	result.targetValidators = nil
+	for i, name := range set.Validators {
+		if _, found := findValidator(name); !found {
+			return result.wrapError("deserialization", fmt.Errorf("invalid validator at index '%v': validator '%v' should be registered", i, name))
+		}
+		result = result.WithValidators(NamedValidator(name))
+	}
+	result.isSecret = set.Secret
+	result.logChangesOnly = set.LogChangesOnly
+	result.logValueFormat = set.LogValueFormat
+	*c = result
+	return nil
+}
+
+func (c Configurator) beSerializable() error {
+	for i, validator := range c.targetValidators {
+		if _, ok := validator.(namedValidator); !ok {
+			return fmt.Errorf("validator at index '%v' should be a named validator", i)
+		}
+	}
+	switch {
+	case len(c.lengthValidators) > 0:
+		return fmt.Errorf("length validators can not be serialized")
+	case len(c.keyValidators) > 0:
+		return fmt.Errorf("key validators can not be serialized")
+	case len(c.elementValidators) > 0:
+		return fmt.Errorf("element validators can not be serialized")
+	case c.elementConfigurator != nil:
+		return fmt.Errorf("element configurator can not be serialized")
+	case len(c.normalizers) > 0:
+		return fmt.Errorf("normalizers can not be serialized")
+	case c.defaultFn != nil:
+		return fmt.Errorf("default function can not be serialized")
+	case len(c.advisories) > 0:
+		return fmt.Errorf("severity rules can not be serialized")
+	case len(c.compareFns) > 0 || len(c.equalFns) > 0:
+		return fmt.Errorf("comparators can not be serialized")
+	case len(c.messages) > 0:
+		return fmt.Errorf("messages can not be serialized")
+	}
+	return nil
+}
+
+func encodeNotNil(value interface{}) (*typedValue, error) {
+	if value == nil {
+		return nil, nil
+	}
+	result, err := encodeValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func encodeArray(values []interface{}) ([]typedValue, error) {
+	if values == nil {
+		return nil, nil
+	}
+	result := make([]typedValue, len(values))
+	var err error
+	for i, value := range values {
+		if result[i], err = encodeValue(value); err != nil {
+			return nil, fmt.Errorf("invalid element at index '%v': %v", i, err)
+		}
+	}
+	return result, nil
+}
+
+func encodeValue(value interface{}) (typedValue, error) {
+	if value == nil {
+		return typedValue{Value: json.RawMessage("null")}, nil
+	}
+	if literal, ok := value.(ruleLiteral); ok {
+		data, err := json.Marshal(string(literal))
+		return typedValue{Type: literalTypeName, Value: data}, err
+	}
+	rType := reflect.TypeOf(value)
+	if rRegisteredType, found := findType(rType.String()); !found || rRegisteredType != rType {
+		return typedValue{}, fmt.Errorf("type '%v' of argument should be registered with RegisterType", rType.String())
+	}
+	if text, ok := encodeText(value); ok {
+		data, err := json.Marshal(text)
+		return typedValue{Type: rType.String(), Value: data}, err
+	}
+	data, err := json.Marshal(value)
+	return typedValue{Type: rType.String(), Value: data}, err
+}
+
+// encodeText encodes value as text if value is decoded from text with converter or 'UnmarshalText' method.
+func encodeText(value interface{}) (string, bool) {
+	rType := reflect.TypeOf(value)
+	if rType == nil || rType.Kind() == reflect.String || !isTextDecodable(rType) {
+		return "", false
+	}
+	switch value := value.(type) {
+	case encoding.TextMarshaler:
+		text, err := value.MarshalText()
+		return string(text), err == nil
+	case fmt.Stringer:
+		return value.String(), true
+	}
+	return "", false
+}
+
+func isTextDecodable(rType reflect.Type) bool {
+	if _, found := findConverter(rType); found {
+		return true
+	}
+	if _, found := findConverter(reflect.PtrTo(rType)); found {
+		return true
+	}
+	return reflect.PtrTo(rType).Implements(textUnmarshalerType)
+}
+
+func decodeNotNil(value *typedValue) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return decodeValue(*value)
+}
+
+func decodeArray(values []typedValue) ([]interface{}, error) {
+	if values == nil {
+		return nil, nil
+	}
+	result := make([]interface{}, len(values))
+	var err error
+	for i, value := range values {
+		if result[i], err = decodeValue(value); err != nil {
+			return nil, fmt.Errorf("invalid element at index '%v': %v", i, err)
+		}
+	}
+	return result, nil
+}
+
+func decodeValue(value typedValue) (interface{}, error) {
+	if value.Type == "" {
+		var result interface{}
+		err := json.Unmarshal(value.Value, &result)
+		return result, err
+	}
+	if value.Type == literalTypeName {
+		var literal string
+		if err := json.Unmarshal(value.Value, &literal); err != nil {
+			return nil, err
+		}
+		return ruleLiteral(literal), nil
+	}
+	rType, found := findType(value.Type)
+	if !found {
+		return nil, fmt.Errorf("type '%v' should be registered with RegisterType", value.Type)
+	}
+	var text string
+	if rType.Kind() != reflect.String && isTextDecodable(rType) && json.Unmarshal(value.Value, &text) == nil {
+		rResult, _, err := convertString(rType, text)
+		if err != nil {
+			return nil, fmt.Errorf("text '%v' should be converted to type '%v': %v", text, rType.String(), err)
+		}
+		return rResult.Interface(), nil
+	}
+	rResult := reflect.New(rType)
+	if err := json.Unmarshal(value.Value, rResult.Interface()); err != nil {
+		return nil, err
+	}
+	return rResult.Elem().Interface(), nil
+}
@@ -0,0 +1,113 @@
+package configuring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigurator_MarshalJSON(t *testing.T) {
+	RegisterValidator("even", ValidatorFunc(func(target interface{}) error {
+		if value, ok := target.(time.Duration); !ok || value%2 != 0 {
+			return fmt.Errorf("argument '%v' should be even", target)
+		}
+		return nil
+	}))
+	configurator := NewConfigurator().Scope("Server").WithName("Timeout").WithMin(time.Second).WithMax(time.Minute).
+		WithDisallowed(30 * time.Second).WithDefault(5 * time.Second).WithFallbacks(10 * time.Second).
+		WithValidators(NamedValidator("even")).Secret().WithLogChangesOnly(true)
+	data, err := json.Marshal(configurator)
+	expected := `{"name":"Timeout","scope":"Server","min":{"type":"time.Duration","value":"1s"},"max":{"type":"time.Duration","value":"1m0s"},` +
+		`"disallowed":[{"type":"time.Duration","value":"30s"}],"default":{"type":"time.Duration","value":"5s"},` +
+		`"fallbacks":[{"type":"time.Duration","value":"10s"}],"validators":["even"],"secret":true,"log_changes_only":true}`
+	if err != nil || string(data) != expected {
+		t.Errorf("expected '%v', was '%v' ('%v')", expected, string(data), err)
+	}
+	var result Configurator
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	timeout := time.Duration(0)
+	if err := result.Configure(&timeout); err != nil || timeout != 10*time.Second {
+		t.Errorf("expected '%v', was '%v' ('%v')", 10*time.Second, timeout, err)
+	}
+	err = result.Validate(30 * time.Second)
+	if err == nil || err.Error() != "validation of 'Server.Timeout' error: argument should not be in disallowed values ['30s']" {
+		t.Errorf("expected '%v', was '%v'", "validation of 'Server.Timeout' error: argument should not be in disallowed values ['30s']", err)
+	}
+	err = result.Validate(time.Second + 1)
+	if err == nil || err.Error() != "validation of 'Server.Timeout' error: argument should be validated with validator at index '0': argument '1.000000001s' should be even" {
+		t.Errorf("expected '%v', was '%v'", "validation of 'Server.Timeout' error: argument should be validated with validator at index '0': argument '1.000000001s' should be even", err)
+	}
+	if data, err := json.Marshal(result); err != nil || string(data) != expected {
+		t.Errorf("expected '%v', was '%v' ('%v')", expected, string(data), err)
+	}
+	_, err = json.Marshal(NewConfigurator().WithName("Port").WithValidators(ValidatorFunc(func(interface{}) error { return nil })))
+	if err == nil || !strings.HasSuffix(err.Error(), "serialization of 'Port' error: validator at index '0' should be a named validator") {
+		t.Errorf("expected '%v', was '%v'", "serialization of 'Port' error: validator at index '0' should be a named validator", err)
+	}
+	type port int
+	_, err = json.Marshal(NewConfigurator().WithName("Port").WithMin(port(1)))
+	if err == nil || !strings.HasSuffix(err.Error(), "serialization of 'Port' error: invalid min value: type 'configuring.port' of argument should be registered with RegisterType") {
+		t.Errorf("expected '%v', was '%v'", "serialization of 'Port' error: invalid min value: type 'configuring.port' of argument should be registered with RegisterType", err)
+	}
+}
+
+func TestConfigurator_UnmarshalJSON(t *testing.T) {
+	var configurator Configurator
+	err := json.Unmarshal([]byte(`{"name":"Port","min":{"type":"int","value":1},"max":{"value":65535},"allowed":[{"type":"literal","value":"80"},{"type":"int","value":443}]}`), &configurator)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = configurator.Validate(8080)
+	if err == nil || err.Error() != "validation of 'Port' error: argument should be in allowed values ['80','443']" {
+		t.Errorf("expected '%v', was '%v'", "validation of 'Port' error: argument should be in allowed values ['80','443']", err)
+	}
+	if err := configurator.Validate(80); err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = json.Unmarshal([]byte(`{"name":"Port","min":{"type":"port","value":1}}`), &configurator)
+	if err == nil || err.Error() != "deserialization of 'Port' error: invalid min value: type 'port' should be registered with RegisterType" {
+		t.Errorf("expected '%v', was '%v'", "deserialization of 'Port' error: invalid min value: type 'port' should be registered with RegisterType", err)
+	}
+	err = json.Unmarshal([]byte(`{"name":"Port","validators":["unknown"]}`), &configurator)
+	if err == nil || err.Error() != "deserialization of 'Port' error: invalid validator at index '0': validator 'unknown' should be registered" {
+		t.Errorf("expected '%v', was '%v'", "deserialization of 'Port' error: invalid validator at index '0': validator 'unknown' should be registered", err)
+	}
+}
+
+func TestConfigurator_UnmarshalJSON_Replace(t *testing.T) {
+	RegisterValidator("positive", ValidatorFunc(func(target interface{}) error {
+		if target.(int) <= 0 {
+			return fmt.Errorf("argument should be positive")
+		}
+		return nil
+	}))
+	configurator := NewConfigurator().WithDefaultFunc(func(ctx context.Context) (interface{}, error) {
+		return 1, nil
+	})
+	data := []byte(`{"name":"Workers","default":{"type":"int","value":4},"validators":["positive"],"secret":true}`)
+	if err := json.Unmarshal(data, &configurator); err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	if err := json.Unmarshal(data, &configurator); err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	if len(configurator.targetValidators) != 1 || !configurator.isSecret || configurator.defaultValue != 4 {
+		t.Errorf("expected '%v', was '%v'", []interface{}{1, true, 4}, []interface{}{len(configurator.targetValidators), configurator.isSecret, configurator.defaultValue})
+	}
+	if err := json.Unmarshal([]byte(`{"name":"Workers"}`), &configurator); err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	if len(configurator.targetValidators) != 0 || configurator.isSecret || configurator.defaultValue != nil || configurator.defaultFn != nil {
+		t.Errorf("expected '%v', was '%v'", []interface{}{0, false, nil}, []interface{}{len(configurator.targetValidators), configurator.isSecret, configurator.defaultValue})
+	}
+	workers := -1
+	err := configurator.Configure(&workers)
+	if err != nil || workers != -1 {
+		t.Errorf("expected '%v', was '%v' ('%v')", -1, workers, err)
+	}
+}
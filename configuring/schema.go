@@ -0,0 +1,467 @@
+package configuring
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaDialect is the JSON Schema dialect of exported schemas.
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// Schema is a JSON Schema document or subschema.
+type Schema map[string]interface{}
+
+// String returns schema as indented JSON document.
+func (s Schema) String() string {
+	data, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return fmt.Sprintf("invalid schema: %v", err)
+	}
+	return string(data)
+}
+
+// Schema exports configurator rules as JSON Schema of values with type of target.
+// Min and max values are exported as 'minimum' and 'maximum' and should be numbers in JSON.
+// Values of types converted from strings with registered converters (e.g. 'time.Duration') are exported as strings (e.g. '1s')
+// and their min and max values as 'x-minimum' and 'x-maximum'.
+// Allowed and disallowed values are exported as 'enum' and 'not.enum'.
+// Length validators and element validators are exported when they are configurators:
+// min and max of length become 'minLength'/'maxLength', 'minItems'/'maxItems' or 'minProperties'/'maxProperties',
+// key rules become 'propertyNames', element rules and element configurator become 'items' or 'additionalProperties'. Other validators can not be exported and are skipped.
+// Default value is exported as 'default' and secret configurator is marked as 'writeOnly' without default value.
+// Recursive struct types are exported once in '$defs' and referenced with '$ref'.
+// Optional values are exported with schemas of their values.
+func (c Configurator) Schema(target interface{}) (Schema, error) {
+	rTargetType := reflect.TypeOf(target)
+	if rTargetType == nil {
+		return nil, c.wrapError("schema export", c.errorf(MessageNotNil, MessageData{}, "argument should not be nil"))
+	}
+	builder := newSchemaBuilder()
+	schema, err := c.schema(builder, rTargetType)
+	if err != nil {
+		return nil, c.wrapError("schema export", err)
+	}
+	return builder.document(schema), nil
+}
+
+// StructSchema exports JSON Schema of struct target.
+// Fields are exported with JSON names and configured with configurators qualified with Go field paths
+// (e.g. 'Server.Port' for field 'Port' of struct field 'Server', see WithName and Scope) or with rules of struct tags.
+// Configurator of a field takes precedence over rules of struct tag.
+func StructSchema(target interface{}, configurators ...Configurator) (Schema, error) {
+	rTargetType := reflect.TypeOf(target)
+	for rTargetType != nil && rTargetType.Kind() == reflect.Ptr {
+		rTargetType = rTargetType.Elem()
+	}
+	if rTargetType == nil || rTargetType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema export error: argument of type '%v' should be a struct", reflect.TypeOf(target))
+	}
+	fieldConfigurators := make(map[string]Configurator, len(configurators))
+	for _, configurator := range configurators {
+		name := configurator.qualifiedName()
+		if name == "" {
+			return nil, fmt.Errorf("schema export error: configurator should have a name")
+		}
+		fieldConfigurators[name] = configurator
+	}
+	builder := newSchemaBuilder()
+	schema, err := builder.structSchema(rTargetType, "", fieldConfigurators)
+	if err != nil {
+		return nil, fmt.Errorf("schema export error: %v", err)
+	}
+	if len(fieldConfigurators) > 0 {
+		names := make([]string, 0, len(fieldConfigurators))
+		for name := range fieldConfigurators {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("schema export of '%v' error: field not found", names[0])
+	}
+	return builder.document(schema), nil
+}
+
+// schemaBuilder exports schemas of types and keeps definitions of recursive struct types referenced with '$ref'.
+type schemaBuilder struct {
+	visiting    map[reflect.Type]bool
+	names       map[reflect.Type]string
+	definitions Schema
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{visiting: map[reflect.Type]bool{}, names: map[reflect.Type]string{}, definitions: Schema{}}
+}
+
+// document returns root schema with dialect and definitions.
+func (b *schemaBuilder) document(schema Schema) Schema {
+	schema["$schema"] = SchemaDialect
+	if len(b.definitions) > 0 {
+		schema["$defs"] = b.definitions
+	}
+	return schema
+}
+
+// reference returns schema referencing definition of struct type in '$defs'.
+func (b *schemaBuilder) reference(rType reflect.Type) Schema {
+	name, found := b.names[rType]
+	if !found {
+		name = rType.String()
+		for i := 2; b.isNameTaken(name); i++ {
+			name = fmt.Sprintf("%v_%v", rType.String(), i)
+		}
+		b.names[rType] = name
+	}
+	return Schema{"$ref": "#/$defs/" + pointerEscaper.Replace(name)}
+}
+
+func (b *schemaBuilder) isNameTaken(name string) bool {
+	for _, takenName := range b.names {
+		if takenName == name {
+			return true
+		}
+	}
+	return false
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// structSchema exports schema of struct type. Struct types exported again while their fields are exported
+// are referenced with '$ref' and exported once in '$defs'.
+func (b *schemaBuilder) structSchema(rType reflect.Type, path string, configurators map[string]Configurator) (Schema, error) {
+	if _, found := b.names[rType]; found || b.visiting[rType] {
+		return b.reference(rType), nil
+	}
+	b.visiting[rType] = true
+	properties := Schema{}
+	err := b.addStructProperties(properties, rType, path, configurators)
+	delete(b.visiting, rType)
+	if err != nil {
+		return nil, err
+	}
+	schema := Schema{"type": "object", "properties": properties}
+	if name, found := b.names[rType]; found {
+		b.definitions[name] = schema
+		return b.reference(rType), nil
+	}
+	return schema, nil
+}
+
+func (b *schemaBuilder) addStructProperties(properties Schema, rType reflect.Type, path string, configurators map[string]Configurator) error {
+	for i := 0; i < rType.NumField(); i++ {
+		rField := rType.Field(i)
+		if rField.PkgPath != "" && !rField.Anonymous {
+			continue
+		}
+		jsonName, hasJSONName := getJSONName(rField)
+		if jsonName == "-" {
+			continue
+		}
+		fieldPath := rField.Name
+		if path != "" {
+			fieldPath = path + "." + rField.Name
+		}
+		rFieldType := rField.Type
+		if rFieldType.Kind() == reflect.Ptr {
+			rFieldType = rFieldType.Elem()
+		}
+		if rField.Anonymous && !hasJSONName && rFieldType.Kind() == reflect.Struct {
+			if err := b.addStructProperties(properties, rFieldType, path, configurators); err != nil {
+				return err
+			}
+			continue
+		}
+		if rField.PkgPath != "" {
+			continue
+		}
+		var property Schema
+		var err error
+		if configurator, found := configurators[fieldPath]; found {
+			delete(configurators, fieldPath)
+			property, err = configurator.schema(b, rField.Type)
+		} else if tag, hasTag := rField.Tag.Lookup(RulesTagName); hasTag {
+			var rules Rules
+			if rules, err = ParseRules(tag); err == nil {
+				property, err = NewConfigurator().WithName(fieldPath).WithRules(rules).schema(b, rField.Type)
+			}
+		} else if rFieldType.Kind() == reflect.Struct && !isTextType(rFieldType) && !isConvertedType(rFieldType) && !rFieldType.Implements(optionalType) {
+			property, err = b.structSchema(rFieldType, fieldPath, configurators)
+		} else {
+			property, err = b.typeSchema(rField.Type)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid field '%v': %v", fieldPath, err)
+		}
+		properties[jsonName] = property
+	}
+	return nil
+}
+
+func getJSONName(rField reflect.StructField) (string, bool) {
+	tag := rField.Tag.Get("json")
+	if i := strings.Index(tag, ","); i >= 0 {
+		tag = tag[:i]
+	}
+	if tag == "" {
+		return rField.Name, false
+	}
+	return tag, true
+}
+
+func isTextType(rType reflect.Type) bool {
+	return rType.Implements(textMarshalerType) || reflect.PtrTo(rType).Implements(textMarshalerType)
+}
+
+// isConvertedType returns true if values of type are converted from strings with registered converter (e.g. 'time.Duration').
+func isConvertedType(rType reflect.Type) bool {
+	if _, found := findConverter(rType); found {
+		return true
+	}
+	_, found := findConverter(reflect.PtrTo(rType))
+	return found
+}
+
+func (b *schemaBuilder) typeSchema(rType reflect.Type) (Schema, error) {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	if rType.Implements(optionalType) {
+		return b.typeSchema(optionalValueType(rType))
+	}
+	if isTextType(rType) || isConvertedType(rType) {
+		return Schema{"type": "string"}, nil
+	}
+	switch rType.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}, nil
+	case reflect.String:
+		return Schema{"type": "string"}, nil
+	case reflect.Interface:
+		return Schema{}, nil
+	case reflect.Slice, reflect.Array:
+		if rType.Kind() == reflect.Slice && rType.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := b.typeSchema(rType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Schema{"type": "array", "items": items}, nil
+	case reflect.Map:
+		additionalProperties, err := b.typeSchema(rType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Schema{"type": "object", "additionalProperties": additionalProperties}, nil
+	case reflect.Struct:
+		return b.structSchema(rType, "", nil)
+	}
+	return nil, fmt.Errorf("type '%v' can not be exported", rType.String())
+}
+
+func (c Configurator) schema(b *schemaBuilder, rTargetType reflect.Type) (Schema, error) {
+	if rTargetType.Implements(optionalType) {
+		rTargetType = optionalValueType(rTargetType)
+	}
+	schema, err := b.typeSchema(rTargetType)
+	if err != nil {
+		return nil, err
+	}
+	if c, err = c.convert(reflect.Zero(rTargetType).Interface()); err != nil {
+		return nil, err
+	}
+	if name := c.qualifiedName(); name != "" {
+		schema["title"] = name
+	}
+	if c.minValue != nil {
+		if err = addSchemaBound(schema, "minimum", c.minValue); err != nil {
+			return nil, fmt.Errorf("invalid min value: %v", err)
+		}
+	}
+	if c.maxValue != nil {
+		if err = addSchemaBound(schema, "maximum", c.maxValue); err != nil {
+			return nil, fmt.Errorf("invalid max value: %v", err)
+		}
+	}
+	if len(c.allowedValues) > 0 {
+		if schema["enum"], err = schemaValues(c.allowedValues); err != nil {
+			return nil, fmt.Errorf("invalid allowed values: %v", err)
+		}
+	}
+	if len(c.disallowedValues) > 0 {
+		values, err := schemaValues(c.disallowedValues)
+		if err != nil {
+			return nil, fmt.Errorf("invalid disallowed values: %v", err)
+		}
+		schema["not"] = Schema{"enum": values}
+	}
+	if c.isSecret {
+		schema["writeOnly"] = true
+	} else if c.defaultValue != nil {
+		if schema["default"], err = schemaValue(c.defaultValue); err != nil {
+			return nil, fmt.Errorf("invalid default value: %v", err)
+		}
+	}
+	var allOf []interface{}
+	for i, validator := range c.targetValidators {
+		if configurator, ok := validator.(Configurator); ok {
+			subschema, err := configurator.schema(b, rTargetType)
+			if err != nil {
+				return nil, fmt.Errorf("invalid validator at index '%v': %v", i, err)
+			}
+			delete(subschema, "type")
+			allOf = append(allOf, subschema)
+		}
+	}
+	if len(allOf) > 0 {
+		schema["allOf"] = allOf
+	}
+	for i, validator := range c.lengthValidators {
+		if configurator, ok := validator.(Configurator); ok {
+			if err := configurator.addLengthSchema(schema); err != nil {
+				return nil, fmt.Errorf("invalid length validator at index '%v': %v", i, err)
+			}
+		}
+	}
+	for i, validator := range c.keyValidators {
+		if configurator, ok := validator.(Configurator); ok {
+			if err := configurator.addKeySchema(b, schema, rTargetType); err != nil {
+				return nil, fmt.Errorf("invalid key validator at index '%v': %v", i, err)
+			}
+		}
+	}
+	for i, validator := range c.elementValidators {
+		if configurator, ok := validator.(Configurator); ok {
+			if err := configurator.addElementSchema(b, schema, rTargetType); err != nil {
+				return nil, fmt.Errorf("invalid element validator at index '%v': %v", i, err)
+			}
+		}
+	}
+	if c.elementConfigurator != nil {
+		if err := c.elementConfigurator.addElementSchema(b, schema, rTargetType); err != nil {
+			return nil, fmt.Errorf("invalid element configurator: %v", err)
+		}
+	}
+	return schema, nil
+}
+
+func (c Configurator) addLengthSchema(schema Schema) error {
+	var err error
+	if c, err = c.convert(int(0)); err != nil {
+		return err
+	}
+	var minKeyword, maxKeyword string
+	switch schema["type"] {
+	case "string":
+		minKeyword, maxKeyword = "minLength", "maxLength"
+	case "array":
+		minKeyword, maxKeyword = "minItems", "maxItems"
+	case "object":
+		minKeyword, maxKeyword = "minProperties", "maxProperties"
+	default:
+		return fmt.Errorf("length of type '%v' can not be exported", schema["type"])
+	}
+	if c.minValue != nil {
+		schema[minKeyword] = c.minValue
+	}
+	if c.maxValue != nil {
+		schema[maxKeyword] = c.maxValue
+	}
+	return nil
+}
+
+func (c Configurator) addKeySchema(b *schemaBuilder, schema Schema, rTargetType reflect.Type) error {
+	for rTargetType.Kind() == reflect.Ptr {
+		rTargetType = rTargetType.Elem()
+	}
+	if schema["type"] != "object" || rTargetType.Key().Kind() != reflect.String {
+		return fmt.Errorf("keys of type '%v' can not be exported", rTargetType.String())
+	}
+	keySchema, err := c.schema(b, rTargetType.Key())
+	if err != nil {
+		return err
+	}
+	delete(keySchema, "type")
+	schema["propertyNames"] = keySchema
+	return nil
+}
+
+func (c Configurator) addElementSchema(b *schemaBuilder, schema Schema, rTargetType reflect.Type) error {
+	for rTargetType.Kind() == reflect.Ptr {
+		rTargetType = rTargetType.Elem()
+	}
+	var keyword string
+	switch schema["type"] {
+	case "array":
+		keyword = "items"
+	case "object":
+		keyword = "additionalProperties"
+	default:
+		return fmt.Errorf("elements of type '%v' can not be exported", schema["type"])
+	}
+	elementSchema, err := c.schema(b, rTargetType.Elem())
+	if err != nil {
+		return err
+	}
+	if previous, ok := schema[keyword].(Schema); ok {
+		for key, value := range previous {
+			if _, found := elementSchema[key]; !found {
+				elementSchema[key] = value
+			}
+		}
+	}
+	schema[keyword] = elementSchema
+	return nil
+}
+
+// addSchemaBound adds bound to numeric schema. Bounds of types converted from strings (e.g. '1s' of 'time.Duration')
+// are added as text with keywords prefixed with 'x-' (e.g. 'x-minimum'), since 'minimum' and 'maximum' apply to numbers only.
+func addSchemaBound(schema Schema, keyword string, value interface{}) error {
+	if text, ok := encodeText(value); ok {
+		schema["x-"+keyword] = text
+		return nil
+	}
+	if schema["type"] != "integer" && schema["type"] != "number" {
+		return fmt.Errorf("bound of type '%v' can not be exported", reflect.TypeOf(value).String())
+	}
+	var err error
+	schema[keyword], err = schemaValue(value)
+	return err
+}
+
+func schemaValues(values []interface{}) ([]interface{}, error) {
+	result := make([]interface{}, len(values))
+	var err error
+	for i := range values {
+		if result[i], err = schemaValue(values[i]); err != nil {
+			return nil, fmt.Errorf("invalid element at index '%v': %v", i, err)
+		}
+	}
+	return result, nil
+}
+
+func schemaValue(value interface{}) (interface{}, error) {
+	if text, ok := encodeText(value); ok {
+		return text, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var result interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
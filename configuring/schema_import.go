@@ -0,0 +1,509 @@
+package configuring
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SchemaRootPath is a JSON path of the root value of imported schema.
+const SchemaRootPath = "$"
+
+var schemaAnnotationKeywords = map[string]bool{
+	"$schema":     true,
+	"$id":         true,
+	"$comment":    true,
+	"title":       true,
+	"description": true,
+	"examples":    true,
+	"deprecated":  true,
+	"readOnly":    true,
+}
+
+// SchemaRules are configurators imported from JSON Schema keyed by JSON path
+// (e.g. '$' for the root value, '$.server.port' for property 'port' of property 'server'
+// and "$['listen.address']" for property 'listen.address').
+// Defaults of properties are added to maps missing their keys, but objects missing in maps are not created (see Configure).
+type SchemaRules struct {
+	// Configurators are configurators keyed by JSON path.
+	Configurators map[string]Configurator
+	// Unsupported are unsupported keywords with JSON path (e.g. '$.server.port: exclusiveMinimum').
+	Unsupported []string
+	// segments are property names of imported JSON paths.
+	segments map[string][]string
+}
+
+// ImportSchema builds configurators from JSON Schema document.
+// Supported keywords: 'minimum', 'maximum', 'enum', 'const', 'not' with 'enum', 'minLength', 'maxLength',
+// 'minItems', 'maxItems', 'minProperties', 'maxProperties', 'pattern', 'default', 'writeOnly' (as secret),
+// 'allOf', 'items', 'additionalProperties', 'propertyNames' and 'properties'.
+// Keywords 'x-minimum' and 'x-maximum' are bounds of values converted from strings (e.g. '1s' of 'time.Duration').
+// Keyword 'type' is validated with JSON types of Go values (e.g. 'time.Duration' and 'net.IP' values are strings)
+// except for type 'object' of schemas with 'properties', which require structs or maps on configuration.
+// Annotations are ignored and other keywords are reported as unsupported.
+// Configurators inherit logger, context and log settings of c.
+func (c Configurator) ImportSchema(data []byte) (SchemaRules, error) {
+	var schema interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return SchemaRules{}, fmt.Errorf("schema import error: %v", err)
+	}
+	rules := SchemaRules{Configurators: map[string]Configurator{}, segments: map[string][]string{}}
+	configurator, err := c.base().importSchema(schema, SchemaRootPath, nil, &rules)
+	if err != nil {
+		return SchemaRules{}, fmt.Errorf("schema import error: %v", err)
+	}
+	if !configurator.isEmpty() {
+		rules.Configurators[SchemaRootPath] = configurator
+	}
+	sort.Strings(rules.Unsupported)
+	return rules, nil
+}
+
+// Configure configures target with imported configurators.
+// Target should be a pointer to a struct or a map with string keys (e.g. 'map[string]interface{}').
+// Struct fields are found by JSON names. Missing map keys of properties with 'default' are added with default values,
+// but missing objects of nested properties are not added (e.g. '$.server.port' is not added if key 'server' is missing).
+func (r SchemaRules) Configure(targetPointer interface{}) error {
+	if err := beConfigurable(targetPointer); err != nil {
+		return fmt.Errorf("configuration error: target value is not configurable: %v", err)
+	}
+	paths := make([]string, 0, len(r.Configurators))
+	for path := range r.Configurators {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		segments, found := r.segments[path]
+		if !found {
+			var err error
+			if segments, err = parseSchemaPath(path); err != nil {
+				return fmt.Errorf("configuration of '%v' error: %v", path, err)
+			}
+		}
+		if err := configureAt(r.Configurators[path], reflect.ValueOf(targetPointer), segments); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func configureAt(configurator Configurator, rPointer reflect.Value, segments []string) error {
+	rValue := rPointer.Elem()
+	switch rValue.Kind() {
+	case reflect.Interface:
+		if rValue.IsNil() {
+			return nil
+		}
+		rDynamicPointer := reflect.New(rValue.Elem().Type())
+		rDynamicPointer.Elem().Set(rValue.Elem())
+		if err := configureAt(configurator, rDynamicPointer, segments); err != nil {
+			return err
+		}
+		rValue.Set(rDynamicPointer.Elem())
+		return nil
+	case reflect.Ptr:
+		if len(segments) > 0 {
+			if rValue.IsNil() {
+				return nil
+			}
+			return configureAt(configurator, rValue, segments)
+		}
+	}
+	if len(segments) == 0 {
+		return configurator.Configure(rPointer.Interface())
+	}
+	switch rValue.Kind() {
+	case reflect.Struct:
+		rField, found := findJSONField(rValue, segments[0])
+		if !found {
+			return fmt.Errorf("configuration of '%v' error: field '%v' of type '%v' not found", configurator.name, segments[0], rValue.Type().String())
+		}
+		return configureAt(configurator, rField.Addr(), segments[1:])
+	case reflect.Map:
+		if rValue.Type().Key().Kind() != reflect.String {
+			break
+		}
+		rKey := reflect.ValueOf(segments[0]).Convert(rValue.Type().Key())
+		rElement := rValue.MapIndex(rKey)
+		if !rElement.IsValid() {
+			return configureMissingAt(configurator, rValue, rKey, segments[1:])
+		}
+		rElementPointer := reflect.New(rElement.Type())
+		rElementPointer.Elem().Set(rElement)
+		if err := configureAt(configurator, rElementPointer, segments[1:]); err != nil {
+			return err
+		}
+		rValue.SetMapIndex(rKey, rElementPointer.Elem())
+		return nil
+	}
+	return fmt.Errorf("configuration of '%v' error: argument of type '%v' should be a struct or a map with string keys", configurator.name, rValue.Type().String())
+}
+
+// configureMissingAt configures zero value of missing key of configured property and adds it to map,
+// so default value of configurator is added. Missing objects of nested properties are not added.
+// Zero values of interface elements have type of default value.
+func configureMissingAt(configurator Configurator, rMap reflect.Value, rKey reflect.Value, segments []string) error {
+	if len(segments) > 0 || configurator.defaultValue == nil {
+		return nil
+	}
+	rElementPointer := reflect.New(rMap.Type().Elem())
+	if rMap.Type().Elem().Kind() == reflect.Interface {
+		rDefaultType := reflect.TypeOf(configurator.defaultValue)
+		if _, ok := configurator.defaultValue.(ruleLiteral); ok || !rDefaultType.AssignableTo(rMap.Type().Elem()) {
+			return nil
+		}
+		rElementPointer.Elem().Set(reflect.Zero(rDefaultType))
+	}
+	if err := configurator.Configure(rElementPointer.Interface()); err != nil {
+		return err
+	}
+	if rElement := rElementPointer.Elem(); !isNil(rElement.Interface()) {
+		rMap.SetMapIndex(rKey, rElement)
+	}
+	return nil
+}
+
+// schemaPath returns JSON path of property of path in dot notation or in bracket notation
+// if name is not an identifier (e.g. "$['listen.address']").
+func schemaPath(path string, name string) string {
+	isIdentifier := name != ""
+	for i, r := range name {
+		if !(r == '_' || unicode.IsLetter(r) || i > 0 && unicode.IsDigit(r)) {
+			isIdentifier = false
+		}
+	}
+	if isIdentifier {
+		return path + "." + name
+	}
+	return path + "['" + pathEscaper.Replace(name) + "']"
+}
+
+var pathEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// parseSchemaPath returns property names of JSON path in dot and bracket notation.
+func parseSchemaPath(path string) ([]string, error) {
+	if !strings.HasPrefix(path, SchemaRootPath) {
+		return nil, fmt.Errorf("path should start with '%v'", SchemaRootPath)
+	}
+	var segments []string
+	for rest := path[len(SchemaRootPath):]; rest != ""; {
+		switch {
+		case strings.HasPrefix(rest, "['"):
+			var name []rune
+			isClosed := false
+			runes := []rune(rest[2:])
+			i := 0
+			for ; i < len(runes) && !isClosed; i++ {
+				switch {
+				case runes[i] == '\\' && i+1 < len(runes):
+					i++
+					name = append(name, runes[i])
+				case runes[i] == '\'' && i+1 < len(runes) && runes[i+1] == ']':
+					isClosed = true
+					i++
+				default:
+					name = append(name, runes[i])
+				}
+			}
+			if !isClosed {
+				return nil, fmt.Errorf("bracket of path '%v' should be closed", path)
+			}
+			segments = append(segments, string(name))
+			rest = string(runes[i:])
+		case strings.HasPrefix(rest, "."):
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("property name of path '%v' should not be empty", path)
+			}
+			segments = append(segments, rest[1:end+1])
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("path '%v' should have properties in dot or bracket notation", path)
+		}
+	}
+	return segments, nil
+}
+
+func findJSONField(rStruct reflect.Value, name string) (reflect.Value, bool) {
+	rStructType := rStruct.Type()
+	var rFoldedField reflect.Value
+	for i := 0; i < rStructType.NumField(); i++ {
+		rField := rStructType.Field(i)
+		if rField.PkgPath != "" {
+			continue
+		}
+		jsonName, hasJSONName := getJSONName(rField)
+		if jsonName == "-" {
+			continue
+		}
+		if rField.Anonymous && !hasJSONName && rField.Type.Kind() == reflect.Struct {
+			if rEmbedded, found := findJSONField(rStruct.Field(i), name); found {
+				return rEmbedded, true
+			}
+			continue
+		}
+		if jsonName == name {
+			return rStruct.Field(i), true
+		}
+		if !rFoldedField.IsValid() && strings.EqualFold(jsonName, name) {
+			rFoldedField = rStruct.Field(i)
+		}
+	}
+	return rFoldedField, rFoldedField.IsValid()
+}
+
+// base returns configurator without rules inheriting scope, logger, context, secret, comparators, parallelism, locale and messages of c.
+func (c Configurator) base() Configurator {
+	return Configurator{
+		scope:          c.scope,
+		ctx:            c.ctx,
+		logFn:          c.logFn,
+		logChangesOnly: c.logChangesOnly,
+		logValueFormat: c.logValueFormat,
+		isSecret:       c.isSecret,
+		compareFns:     c.compareFns,
+		equalFns:       c.equalFns,
+		parallelism:    c.parallelism,
+		locale:         c.locale,
+		messages:       c.messages,
+	}
+}
+
+func (c Configurator) isEmpty() bool {
+	return c.minValue == nil && c.maxValue == nil && len(c.allowedValues) == 0 && len(c.disallowedValues) == 0 && c.defaultValue == nil && c.defaultFn == nil && len(c.fallbackValues) == 0 && len(c.advisories) == 0 &&
+		len(c.targetValidators) == 0 && len(c.lengthValidators) == 0 && len(c.keyValidators) == 0 && len(c.elementValidators) == 0 && c.elementConfigurator == nil
+}
+
+func (c Configurator) importSchema(schema interface{}, path string, segments []string, rules *SchemaRules) (Configurator, error) {
+	if value, ok := schema.(bool); ok && value {
+		return c, nil
+	}
+	object, ok := schema.(map[string]interface{})
+	if !ok {
+		return c, fmt.Errorf("schema of '%v' should be an object", path)
+	}
+	c.name = path
+	keywords := make([]string, 0, len(object))
+	for keyword := range object {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+	var lengthValidator Configurator
+	for _, keyword := range keywords {
+		value := object[keyword]
+		switch keyword {
+		case "minimum", "x-minimum":
+			c.minValue = value
+		case "maximum", "x-maximum":
+			c.maxValue = value
+		case "enum":
+			values, ok := value.([]interface{})
+			if !ok {
+				return c, fmt.Errorf("keyword 'enum' of '%v' should be an array", path)
+			}
+			c.allowedValues = values
+		case "const":
+			c.allowedValues = []interface{}{value}
+		case "not":
+			not, ok := value.(map[string]interface{})
+			values, isArray := not["enum"].([]interface{})
+			if !ok || len(not) != 1 || !isArray {
+				rules.Unsupported = append(rules.Unsupported, fmt.Sprintf("%v: %v", path, keyword))
+				continue
+			}
+			c.disallowedValues = values
+		case "minLength", "minItems", "minProperties":
+			lengthValidator.minValue = value
+		case "maxLength", "maxItems", "maxProperties":
+			lengthValidator.maxValue = value
+		case "pattern":
+			pattern, ok := value.(string)
+			if !ok {
+				return c, fmt.Errorf("keyword 'pattern' of '%v' should be a string", path)
+			}
+			rPattern, err := regexp.Compile(pattern)
+			if err != nil {
+				return c, fmt.Errorf("keyword 'pattern' of '%v' should be a valid regular expression: %v", path, err)
+			}
+			c.targetValidators = append(c.targetValidators, patternValidator{rPattern: rPattern})
+		case "default":
+			c.defaultValue = value
+		case "type":
+			validator, err := newTypeValidator(value)
+			if err != nil {
+				return c, fmt.Errorf("keyword 'type' of '%v' %v", path, err)
+			}
+			if _, hasProperties := object["properties"]; hasProperties && reflect.DeepEqual(validator.types, []string{"object"}) {
+				continue
+			}
+			c.targetValidators = append(c.targetValidators, validator)
+		case "writeOnly":
+			if value == true {
+				c.isSecret = true
+			}
+		case "allOf":
+			subschemas, ok := value.([]interface{})
+			if !ok {
+				return c, fmt.Errorf("keyword 'allOf' of '%v' should be an array", path)
+			}
+			for _, subschema := range subschemas {
+				validator, err := c.base().importSchema(subschema, path, segments, rules)
+				if err != nil {
+					return c, err
+				}
+				c.targetValidators = append(c.targetValidators, validator)
+			}
+		case "items", "additionalProperties":
+			if value == false {
+				rules.Unsupported = append(rules.Unsupported, fmt.Sprintf("%v: %v", path, keyword))
+				continue
+			}
+			elementPath := path + "[*]"
+			elementRules := SchemaRules{Configurators: map[string]Configurator{}, segments: map[string][]string{}}
+			validator, err := c.base().importSchema(value, elementPath, nil, &elementRules)
+			if err != nil {
+				return c, err
+			}
+			rules.Unsupported = append(rules.Unsupported, elementRules.Unsupported...)
+			if len(elementRules.Configurators) > 0 {
+				rules.Unsupported = append(rules.Unsupported, fmt.Sprintf("%v: %v", elementPath, "properties"))
+			}
+			c.elementValidators = append(c.elementValidators, validator)
+		case "propertyNames":
+			validator, err := c.base().importSchema(value, path+"[*]", nil, rules)
+			if err != nil {
+				return c, err
+			}
+			c.keyValidators = append(c.keyValidators, validator)
+		case "properties":
+			properties, ok := value.(map[string]interface{})
+			if !ok {
+				return c, fmt.Errorf("keyword 'properties' of '%v' should be an object", path)
+			}
+			names := make([]string, 0, len(properties))
+			for name := range properties {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				property := properties[name]
+				propertyPath := schemaPath(path, name)
+				propertySegments := append(append([]string(nil), segments...), name)
+				configurator, err := c.base().importSchema(property, propertyPath, propertySegments, rules)
+				if err != nil {
+					return c, err
+				}
+				if !configurator.isEmpty() {
+					rules.Configurators[propertyPath] = configurator
+					rules.segments[propertyPath] = propertySegments
+				}
+			}
+		default:
+			if !schemaAnnotationKeywords[keyword] {
+				rules.Unsupported = append(rules.Unsupported, fmt.Sprintf("%v: %v", path, keyword))
+			}
+		}
+	}
+	if !lengthValidator.isEmpty() {
+		c.lengthValidators = append(c.lengthValidators, lengthValidator)
+	}
+	return c, nil
+}
+
+type patternValidator struct {
+	rPattern *regexp.Regexp
+}
+
+func (v patternValidator) Validate(target interface{}) error {
+	rTarget := indirect(target)
+	if rTarget.Kind() != reflect.String {
+		return fmt.Errorf("argument of type '%v' should be a string", reflect.TypeOf(target))
+	}
+	if !v.rPattern.MatchString(rTarget.String()) {
+		return fmt.Errorf("argument should match pattern '%v'", v.rPattern.String())
+	}
+	return nil
+}
+
+// typeValidator validates that JSON value of target has one of JSON types of keyword 'type'.
+type typeValidator struct {
+	types []string
+}
+
+func newTypeValidator(value interface{}) (typeValidator, error) {
+	var types []string
+	switch value := value.(type) {
+	case string:
+		types = []string{value}
+	case []interface{}:
+		for _, element := range value {
+			name, ok := element.(string)
+			if !ok {
+				return typeValidator{}, fmt.Errorf("should be a string or an array of strings")
+			}
+			types = append(types, name)
+		}
+	default:
+		return typeValidator{}, fmt.Errorf("should be a string or an array of strings")
+	}
+	for _, name := range types {
+		switch name {
+		case "null", "boolean", "integer", "number", "string", "array", "object":
+		default:
+			return typeValidator{}, fmt.Errorf("has unknown type '%v'", name)
+		}
+	}
+	return typeValidator{types: types}, nil
+}
+
+func (v typeValidator) Validate(target interface{}) error {
+	rTarget := indirect(target)
+	for rTarget.Kind() == reflect.Interface && !rTarget.IsNil() {
+		rTarget = indirect(rTarget.Elem().Interface())
+	}
+	for _, name := range v.types {
+		if hasSchemaType(rTarget, name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("argument of type '%v' should have JSON type '%v'", reflect.TypeOf(target), strings.Join(v.types, "' or '"))
+}
+
+// hasSchemaType returns true if value is encoded as JSON value of type
+// (values of types converted from strings, text types and byte slices are strings).
+func hasSchemaType(rValue reflect.Value, name string) bool {
+	if !rValue.IsValid() {
+		return name == "null"
+	}
+	rType := rValue.Type()
+	if isTextType(rType) || isConvertedType(rType) || rType.Kind() == reflect.Slice && rType.Elem().Kind() == reflect.Uint8 {
+		return name == "string" || name == "null" && rValue.Kind() == reflect.Slice && rValue.IsNil()
+	}
+	switch rValue.Kind() {
+	case reflect.Bool:
+		return name == "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return name == "integer" || name == "number"
+	case reflect.Float32, reflect.Float64:
+		return name == "number" || name == "integer" && rValue.Float() == math.Trunc(rValue.Float())
+	case reflect.String:
+		return name == "string"
+	case reflect.Slice, reflect.Array:
+		return name == "array" || name == "null" && rValue.Kind() == reflect.Slice && rValue.IsNil()
+	case reflect.Map:
+		return name == "object" || name == "null" && rValue.IsNil()
+	case reflect.Struct:
+		return name == "object"
+	case reflect.Ptr, reflect.Interface:
+		return name == "null" && rValue.IsNil()
+	}
+	return false
+}
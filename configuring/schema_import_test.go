@@ -0,0 +1,217 @@
+package configuring
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+const testImportSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["server"],
+	"properties": {
+		"server": {
+			"type": "object",
+			"properties": {
+				"host": {"type": "string", "pattern": "^[a-z.]+$", "minLength": 1, "default": "localhost"},
+				"port": {"type": "integer", "minimum": 1, "maximum": 65535, "exclusiveMinimum": 0},
+				"token": {"type": "string", "writeOnly": true, "not": {"enum": [""]}}
+			}
+		},
+		"level": {"enum": ["debug", "info"], "default": "info"},
+		"hosts": {"type": "array", "maxItems": 2, "items": {"type": "string", "maxLength": 3, "format": "hostname"}}
+	}
+}`
+
+func TestConfigurator_ImportSchema(t *testing.T) {
+	var messages []string
+	rules, err := NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}).ImportSchema([]byte(testImportSchema))
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	if expected := []string{"$.hosts[*]: format", "$.server.port: exclusiveMinimum", "$: required"}; !reflect.DeepEqual(rules.Unsupported, expected) {
+		t.Errorf("expected '%v', was '%v'", expected, rules.Unsupported)
+	}
+	var paths []string
+	for path := range rules.Configurators {
+		paths = append(paths, path)
+	}
+	if len(paths) != 5 {
+		t.Errorf("expected '%v', was '%v'", 5, paths)
+	}
+	type Server struct {
+		Host  string
+		Port  int `json:"port"`
+		Token string
+	}
+	type Config struct {
+		Server *Server
+		Level  string
+		Hosts  []string
+	}
+	config := Config{Server: &Server{Port: 80, Token: "secret"}, Hosts: []string{"a", "b"}}
+	if err := rules.Configure(&config); err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if expected := (Config{Server: &Server{Host: "localhost", Port: 80, Token: "secret"}, Level: "info", Hosts: []string{"a", "b"}}); !reflect.DeepEqual(config, expected) {
+		t.Errorf("expected '%v', was '%v'", expected, config)
+	}
+	if expected := "configuration of '$.server.token': disallowed: [''] input: *secret* output: *secret*"; len(messages) != 5 || messages[4] != expected {
+		t.Errorf("expected '%v', was '%v'", expected, messages)
+	}
+	config.Server.Port = 0
+	err = rules.Configure(&config)
+	if err == nil || err.Error() != "configuration of '$.server.port' error: target value error: argument should be greater than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "configuration of '$.server.port' error: target value error: argument should be greater than or equal to '1'", err)
+	}
+	config = Config{Server: &Server{Port: 1, Host: "UPPER", Token: "secret"}}
+	if err := rules.Configure(&config); err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if config.Server.Host != "localhost" {
+		t.Errorf("expected '%v', was '%v'", "localhost", config.Server.Host)
+	}
+	config = Config{Hosts: []string{"a", "b", "c"}}
+	err = rules.Configure(&config)
+	if err == nil || err.Error() != "configuration of '$.hosts' error: target value error: argument length should be validated with validator at index '0': validation error: argument should be lower than or equal to '2'" {
+		t.Errorf("expected '%v', was '%v'", "configuration of '$.hosts' error: target value error: argument length should be validated with validator at index '0': validation error: argument should be lower than or equal to '2'", err)
+	}
+	err = rules.Configurators["$.server.host"].Validate("UPPER")
+	if err == nil || err.Error() != "validation of '$.server.host' error: argument should be validated with validator at index '0': argument should match pattern '^[a-z.]+$'" {
+		t.Errorf("expected '%v', was '%v'", "validation of '$.server.host' error: argument should be validated with validator at index '0': argument should match pattern '^[a-z.]+$'", err)
+	}
+	values := map[string]interface{}{
+		"server": map[string]interface{}{"port": float64(8080), "token": "secret"},
+		"hosts":  []interface{}{"abc"},
+	}
+	if err := rules.Configure(&values); err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if expected := map[string]interface{}{
+		"server": map[string]interface{}{"host": "localhost", "port": float64(8080), "token": "secret"},
+		"level":  "info",
+		"hosts":  []interface{}{"abc"},
+	}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected '%v', was '%v'", expected, values)
+	}
+	values["hosts"] = []interface{}{"abcd"}
+	err = rules.Configure(&values)
+	if err == nil || err.Error() != "configuration of '$.hosts' error: target value error: argument element at index '0' should be validated with validator at index '0': validation of '$.hosts[*]' error: argument length should be validated with validator at index '0': validation error: argument should be lower than or equal to '3'" {
+		t.Errorf("expected '%v', was '%v'", "configuration of '$.hosts' error: target value error: argument element at index '0' should be validated with validator at index '0': validation of '$.hosts[*]' error: argument length should be validated with validator at index '0': validation error: argument should be lower than or equal to '3'", err)
+	}
+	values = map[string]interface{}{}
+	if err := rules.Configure(&values); err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if expected := map[string]interface{}{"level": "info"}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected '%v', was '%v'", expected, values)
+	}
+	for i := 0; i < 10; i++ {
+		_, err = NewConfigurator().ImportSchema([]byte(`{"properties": {"c": 1, "b": 1, "a": 1}}`))
+		if err == nil || err.Error() != "schema import error: schema of '$.a' should be an object" {
+			t.Errorf("expected '%v', was '%v'", "schema import error: schema of '$.a' should be an object", err)
+		}
+	}
+}
+
+func TestConfigurator_ImportSchema_Type(t *testing.T) {
+	rules, err := NewConfigurator().ImportSchema([]byte(`{"type": ["integer", "null"]}`))
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	validator := rules.Configurators[SchemaRootPath]
+	for _, value := range []interface{}{1, uint8(2), float64(3), (*int)(nil)} {
+		if err := validator.Validate(value); err != nil {
+			t.Errorf("expected '%v', was '%v'", error(nil), err)
+		}
+	}
+	err = validator.Validate(1.5)
+	if err == nil || err.Error() != "validation of '$' error: argument should be validated with validator at index '0': argument of type 'float64' should have JSON type 'integer' or 'null'" {
+		t.Errorf("expected '%v', was '%v'", "validation of '$' error: argument should be validated with validator at index '0': argument of type 'float64' should have JSON type 'integer' or 'null'", err)
+	}
+	rules, err = NewConfigurator().ImportSchema([]byte(`{"type": "string"}`))
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	for _, value := range []interface{}{"a", time.Second, net.IPv4(127, 0, 0, 1)} {
+		if err := rules.Configurators[SchemaRootPath].Validate(value); err != nil {
+			t.Errorf("expected '%v', was '%v'", error(nil), err)
+		}
+	}
+	rules, err = NewConfigurator().ImportSchema([]byte(`{"type": "object", "properties": {"port": {"type": "integer"}}}`))
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	if len(rules.Configurators) != 1 {
+		t.Errorf("expected '%v', was '%v'", 1, rules.Configurators)
+	}
+	err = rules.Configure(&map[string]interface{}{"port": "8080"})
+	if err == nil || err.Error() != "configuration of '$.port' error: target value error: argument should be validated with validator at index '0': argument of type 'string' should have JSON type 'integer'" {
+		t.Errorf("expected '%v', was '%v'", "configuration of '$.port' error: target value error: argument should be validated with validator at index '0': argument of type 'string' should have JSON type 'integer'", err)
+	}
+	schema, err := NewConfigurator().WithMin(time.Second).Schema(time.Duration(0))
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	if rules, err = NewConfigurator().ImportSchema([]byte(schema.String())); err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = rules.Configurators[SchemaRootPath].Validate(time.Millisecond)
+	if err == nil || err.Error() != "validation of '$' error: argument should be greater than or equal to '1s'" {
+		t.Errorf("expected '%v', was '%v'", "validation of '$' error: argument should be greater than or equal to '1s'", err)
+	}
+	_, err = NewConfigurator().ImportSchema([]byte(`{"type": "decimal"}`))
+	if err == nil || err.Error() != "schema import error: keyword 'type' of '$' has unknown type 'decimal'" {
+		t.Errorf("expected '%v', was '%v'", "schema import error: keyword 'type' of '$' has unknown type 'decimal'", err)
+	}
+}
+
+func TestSchemaRules_Configure_Paths(t *testing.T) {
+	rules, err := NewConfigurator().ImportSchema([]byte(`{"properties": {
+		"listen.address": {"minLength": 1, "default": "localhost"},
+		"listen": {"properties": {"address": {"minLength": 1, "default": "0.0.0.0"}}},
+		"it's": {"minLength": 1, "default": "quoted"}
+	}}`))
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	var paths []string
+	for path := range rules.Configurators {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	if expected := []string{`$.listen.address`, `$['it\'s']`, `$['listen.address']`}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected '%v', was '%v'", expected, paths)
+	}
+	rules.Configurators[`$['a.b'].c`] = NewConfigurator().WithDisallowed("").WithDefault("added")
+	type Listen struct {
+		Address string `json:"address"`
+	}
+	type Added struct {
+		C string `json:"c"`
+	}
+	type Config struct {
+		ListenAddress string `json:"listen.address"`
+		Listen        Listen `json:"listen"`
+		Quoted        string `json:"it's"`
+		Added         Added  `json:"a.b"`
+	}
+	var config Config
+	if err := rules.Configure(&config); err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if expected := (Config{ListenAddress: "localhost", Listen: Listen{Address: "0.0.0.0"}, Quoted: "quoted", Added: Added{C: "added"}}); config != expected {
+		t.Errorf("expected '%v', was '%v'", expected, config)
+	}
+	rules.Configurators[`$['a`] = NewConfigurator()
+	err = rules.Configure(&config)
+	if err == nil || err.Error() != "configuration of '$['a' error: bracket of path '$['a' should be closed" {
+		t.Errorf("expected '%v', was '%v'", "configuration of '$['a' error: bracket of path '$['a' should be closed", err)
+	}
+}
@@ -0,0 +1,123 @@
+package configuring
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+)
+
+func toJSON(t *testing.T, value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	return string(data)
+}
+
+func TestConfigurator_Schema(t *testing.T) {
+	schema, err := NewConfigurator().WithName("Level").WithAllowed("debug", "info").WithDisallowed("trace").WithDefault("info").Schema("")
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := toJSON(t, schema); result != `{"$schema":"https://json-schema.org/draft/2020-12/schema","default":"info","enum":["debug","info"],"not":{"enum":["trace"]},"title":"Level","type":"string"}` {
+		t.Errorf("expected '%v', was '%v'", `{"$schema":"https://json-schema.org/draft/2020-12/schema","default":"info","enum":["debug","info"],"not":{"enum":["trace"]},"title":"Level","type":"string"}`, result)
+	}
+	schema, err = NewConfigurator().WithMin(time.Second).WithMax(time.Minute).Secret().WithDefault(2 * time.Second).Schema(time.Duration(0))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := toJSON(t, schema); result != `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"string","writeOnly":true,"x-maximum":"1m0s","x-minimum":"1s"}` {
+		t.Errorf("expected '%v', was '%v'", `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"string","writeOnly":true,"x-maximum":"1m0s","x-minimum":"1s"}`, result)
+	}
+	schema, err = NewConfigurator().WithAllowed("1s", "2s").WithDefault("2s").Schema(time.Duration(0))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := toJSON(t, schema); result != `{"$schema":"https://json-schema.org/draft/2020-12/schema","default":"2s","enum":["1s","2s"],"type":"string"}` {
+		t.Errorf("expected '%v', was '%v'", `{"$schema":"https://json-schema.org/draft/2020-12/schema","default":"2s","enum":["1s","2s"],"type":"string"}`, result)
+	}
+	schema, err = NewConfigurator().WithLengthValidators(NewConfigurator().WithMin(1).WithMax(3)).WithElementValidators(NewConfigurator().WithMin(1)).Schema([]int(nil))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := toJSON(t, schema); result != `{"$schema":"https://json-schema.org/draft/2020-12/schema","items":{"minimum":1,"type":"integer"},"maxItems":3,"minItems":1,"type":"array"}` {
+		t.Errorf("expected '%v', was '%v'", `{"$schema":"https://json-schema.org/draft/2020-12/schema","items":{"minimum":1,"type":"integer"},"maxItems":3,"minItems":1,"type":"array"}`, result)
+	}
+	schema, err = NewConfigurator().WithLengthValidators(NewConfigurator().WithMin(1)).Schema("")
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := toJSON(t, schema); result != `{"$schema":"https://json-schema.org/draft/2020-12/schema","minLength":1,"type":"string"}` {
+		t.Errorf("expected '%v', was '%v'", `{"$schema":"https://json-schema.org/draft/2020-12/schema","minLength":1,"type":"string"}`, result)
+	}
+	schema, err = NewConfigurator().WithKeyValidators(NewConfigurator().WithDisallowed("")).WithElementValidators(NewConfigurator().WithMax(10)).Schema(map[string]int(nil))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := toJSON(t, schema); result != `{"$schema":"https://json-schema.org/draft/2020-12/schema","additionalProperties":{"maximum":10,"type":"integer"},"propertyNames":{"not":{"enum":[""]}},"type":"object"}` {
+		t.Errorf("expected '%v', was '%v'", `{"$schema":"https://json-schema.org/draft/2020-12/schema","additionalProperties":{"maximum":10,"type":"integer"},"propertyNames":{"not":{"enum":[""]}},"type":"object"}`, result)
+	}
+	_, err = NewConfigurator().WithName("Address").WithMin("a").Schema("")
+	if err == nil || err.Error() != "schema export of 'Address' error: invalid min value: bound of type 'string' can not be exported" {
+		t.Errorf("expected '%v', was '%v'", "schema export of 'Address' error: invalid min value: bound of type 'string' can not be exported", err)
+	}
+	_, err = NewConfigurator().Schema(nil)
+	if err == nil || err.Error() != "schema export error: argument should not be nil" {
+		t.Errorf("expected '%v', was '%v'", "schema export error: argument should not be nil", err)
+	}
+}
+
+func TestStructSchema(t *testing.T) {
+	type TLS struct {
+		CertFile string `json:"cert_file"`
+	}
+	type Server struct {
+		Address net.IP
+		Port    int `json:"port,omitempty"`
+		TLS     *TLS
+		Ignored int `json:"-"`
+		hidden  int
+	}
+	schema, err := StructSchema(&Server{},
+		NewConfigurator().WithName("Port").WithMin(1).WithMax(65535).WithDefault(8080),
+		NewConfigurator().WithName("TLS.CertFile").WithDisallowed(""),
+	)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := toJSON(t, schema); result != `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"Address":{"type":"string"},"TLS":{"properties":{"cert_file":{"not":{"enum":[""]},"title":"TLS.CertFile","type":"string"}},"type":"object"},"port":{"default":8080,"maximum":65535,"minimum":1,"title":"Port","type":"integer"}},"type":"object"}` {
+		t.Errorf("expected '%v', was '%v'", `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"Address":{"type":"string"},"TLS":{"properties":{"cert_file":{"not":{"enum":[""]},"title":"TLS.CertFile","type":"string"}},"type":"object"},"port":{"default":8080,"maximum":65535,"minimum":1,"title":"Port","type":"integer"}},"type":"object"}`, result)
+	}
+	_, err = StructSchema(Server{}, NewConfigurator().WithName("Timeout"))
+	if err == nil || err.Error() != "schema export of 'Timeout' error: field not found" {
+		t.Errorf("expected '%v', was '%v'", "schema export of 'Timeout' error: field not found", err)
+	}
+	type Node struct {
+		Name     string
+		Next     *Node
+		Children []Node
+	}
+	type Tree struct {
+		Root  Node
+		Depth time.Duration
+	}
+	schema, err = StructSchema(&Tree{})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := toJSON(t, schema); result != `{"$defs":{"configuring.Node":{"properties":{"Children":{"items":{"$ref":"#/$defs/configuring.Node"},"type":"array"},"Name":{"type":"string"},"Next":{"$ref":"#/$defs/configuring.Node"}},"type":"object"}},"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"Depth":{"type":"string"},"Root":{"$ref":"#/$defs/configuring.Node"}},"type":"object"}` {
+		t.Errorf("expected '%v', was '%v'", `{"$defs":{"configuring.Node":{"properties":{"Children":{"items":{"$ref":"#/$defs/configuring.Node"},"type":"array"},"Name":{"type":"string"},"Next":{"$ref":"#/$defs/configuring.Node"}},"type":"object"}},"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"Depth":{"type":"string"},"Root":{"$ref":"#/$defs/configuring.Node"}},"type":"object"}`, result)
+	}
+	schema, err = StructSchema(&Node{})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := toJSON(t, schema); result != `{"$defs":{"configuring.Node":{"properties":{"Children":{"items":{"$ref":"#/$defs/configuring.Node"},"type":"array"},"Name":{"type":"string"},"Next":{"$ref":"#/$defs/configuring.Node"}},"type":"object"}},"$ref":"#/$defs/configuring.Node","$schema":"https://json-schema.org/draft/2020-12/schema"}` {
+		t.Errorf("expected '%v', was '%v'", `{"$defs":{"configuring.Node":{"properties":{"Children":{"items":{"$ref":"#/$defs/configuring.Node"},"type":"array"},"Name":{"type":"string"},"Next":{"$ref":"#/$defs/configuring.Node"}},"type":"object"}},"$ref":"#/$defs/configuring.Node","$schema":"https://json-schema.org/draft/2020-12/schema"}`, result)
+	}
+	_, err = StructSchema(1)
+	if err == nil || err.Error() != "schema export error: argument of type 'int' should be a struct" {
+		t.Errorf("expected '%v', was '%v'", "schema export error: argument of type 'int' should be a struct", err)
+	}
+}
@@ -0,0 +1,57 @@
+package configuring
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Scope returns configurator of nested scope of c named with dotted path (e.g. 'Server.TLS' for Scope("Server").Scope("TLS")).
+// Names of configurations of scope are prefixed with the path (e.g. 'Server.TLS.CertFile' for WithName("CertFile")).
+// Scope inherits logger, context, secret, comparators, parallelism, locale and messages of c but not rules of c.
+func (c Configurator) Scope(name string) Configurator {
+	scope := c.base()
+	scope.scope = c.qualifiedName()
+	if scope.scope != "" && name != "" {
+		scope.scope = scope.scope + "." + name
+	} else if name != "" {
+		scope.scope = name
+	}
+	return scope
+}
+
+// qualifiedName returns name of configuration prefixed with path of scope.
+func (c Configurator) qualifiedName() string {
+	if c.scope == "" {
+		return c.name
+	}
+	if c.name == "" {
+		return c.scope
+	}
+	return c.scope + "." + c.name
+}
+
+// EnvName returns name of environment variable derived from qualified name of configuration
+// (e.g. 'SERVER_TLS_CERT_FILE' for 'Server.TLS.CertFile' and 'HOSTS_3' for 'Hosts[3]').
+func (c Configurator) EnvName() string {
+	runes := []rune(c.qualifiedName())
+	var builder strings.Builder
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			r = '_'
+		} else if i > 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || unicode.IsUpper(previous) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				_, _ = builder.WriteRune('_')
+			}
+		}
+		_, _ = builder.WriteRune(unicode.ToUpper(r))
+	}
+	return strings.Trim(collapseUnderscores(builder.String()), "_")
+}
+
+func collapseUnderscores(value string) string {
+	for strings.Contains(value, "__") {
+		value = strings.Replace(value, "__", "_", -1)
+	}
+	return value
+}
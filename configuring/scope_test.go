@@ -0,0 +1,99 @@
+package configuring
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestConfigurator_Scope(t *testing.T) {
+	var messages []string
+	ctx := context.WithValue(context.Background(), "request", "reload")
+	root := NewConfigurator().WithContext(ctx).WithLogger(func(ctx context.Context, format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf("%v: ", ctx.Value("request"))+fmt.Sprintf(format, args...))
+	}).WithMin(1)
+	tls := root.Scope("Server").Scope("TLS")
+	err := tls.WithName("CertFile").WithDefault("cert.pem").Configure(new(string))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = tls.Secret().WithName("KeyPassword").WithDisallowed("").Configure(new(string))
+	if err == nil || err.Error() != "configuration of 'Server.TLS.KeyPassword' error: target value error: argument should not be in disallowed values ['']" {
+		t.Errorf("expected '%v', was '%v'", "configuration of 'Server.TLS.KeyPassword' error: target value error: argument should not be in disallowed values ['']", err)
+	}
+	err = root.WithName("Service").Scope("Workers").Configure(new(int))
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = tls.Scope("").WithElementConfigurator(NewConfigurator().WithDisallowed("")).Configure(&[]string{"a", ""})
+	if err == nil || err.Error() != "configuration of 'Server.TLS' error: target value error: configuration of 'Server.TLS[1]' error: target value error: argument should not be in disallowed values ['']" {
+		t.Errorf("expected '%v', was '%v'", "configuration of 'Server.TLS' error: target value error: configuration of 'Server.TLS[1]' error: target value error: argument should not be in disallowed values ['']", err)
+	}
+	expected := []string{
+		"reload: configuration of 'Server.TLS.CertFile': default: 'cert.pem' input: '' output: ''",
+		"reload: configuration of 'Service.Workers': input: '0' output: '0'",
+		"reload: configuration of 'Server.TLS[0]': disallowed: [''] input: 'a' output: 'a'",
+	}
+	if fmt.Sprint(messages) != fmt.Sprint(expected) {
+		t.Errorf("expected '%v', was '%v'", expected, messages)
+	}
+	schema, err := tls.WithName("CertFile").Schema(new(string))
+	if err != nil || schema["title"] != "Server.TLS.CertFile" {
+		t.Errorf("expected '%v', was '%v' ('%v')", "Server.TLS.CertFile", schema["title"], err)
+	}
+}
+
+func TestConfigurator_EnvName(t *testing.T) {
+	testCases := map[string]string{
+		"":                    "",
+		"Port":                "PORT",
+		"Server.TLS.CertFile": "SERVER_TLS_CERT_FILE",
+		"HTTPServer.Timeout":  "HTTP_SERVER_TIMEOUT",
+		"Hosts[3]":            "HOSTS_3",
+		"api.v2.base_url":     "API_V2_BASE_URL",
+	}
+	for name, expected := range testCases {
+		if result := NewConfigurator().WithName(name).EnvName(); result != expected {
+			t.Errorf("expected '%v', was '%v'", expected, result)
+		}
+	}
+	if result := NewConfigurator().Scope("Server").Scope("TLS").WithName("CertFile").EnvName(); result != "SERVER_TLS_CERT_FILE" {
+		t.Errorf("expected '%v', was '%v'", "SERVER_TLS_CERT_FILE", result)
+	}
+}
+
+func TestConfigurator_Scope_Structs(t *testing.T) {
+	type TLS struct {
+		CertFile string `json:"cert_file" configuring:"noneof=''"`
+	}
+	type Server struct {
+		Port int `json:"port"`
+		TLS  TLS `json:"tls"`
+	}
+	server := NewConfigurator().Scope("Server")
+	schema, err := StructSchema(&Server{}, server.WithName("Port").WithMin(1))
+	if err == nil || err.Error() != "schema export of 'Server.Port' error: field not found" {
+		t.Errorf("expected '%v', was '%v'", "schema export of 'Server.Port' error: field not found", err)
+	}
+	schema, err = StructSchema(&struct {
+		Server Server `json:"server"`
+	}{}, server.WithName("Port").WithMin(1))
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	if result := toJSON(t, schema); result != `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"server":{"properties":{"port":{"minimum":1,"title":"Server.Port","type":"integer"},"tls":{"properties":{"cert_file":{"not":{"enum":[""]},"title":"Server.TLS.CertFile","type":"string"}},"type":"object"}},"type":"object"}},"type":"object"}` {
+		t.Errorf("expected '%v', was '%v'", `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"server":{"properties":{"port":{"minimum":1,"title":"Server.Port","type":"integer"},"tls":{"properties":{"cert_file":{"not":{"enum":[""]},"title":"Server.TLS.CertFile","type":"string"}},"type":"object"}},"type":"object"}},"type":"object"}`, result)
+	}
+	err = server.ConfigureStruct(&Server{})
+	if err == nil || err.Error() != "configuration of 'Server.TLS.CertFile' error: target value error: argument should not be in disallowed values ['']" {
+		t.Errorf("expected '%v', was '%v'", "configuration of 'Server.TLS.CertFile' error: target value error: argument should not be in disallowed values ['']", err)
+	}
+	rules, err := server.ImportSchema([]byte(`{"properties": {"port": {"minimum": 1}}}`))
+	if err != nil {
+		t.Fatalf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = rules.Configure(&Server{})
+	if err == nil || err.Error() != "configuration of 'Server.$.port' error: target value error: argument should be greater than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "configuration of 'Server.$.port' error: target value error: argument should be greater than or equal to '1'", err)
+	}
+}
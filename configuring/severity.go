@@ -0,0 +1,115 @@
+package configuring
+
+import (
+	"fmt"
+)
+
+// Severity is severity of rules. Only rules with SeverityError affect result of configuration.
+type Severity int
+
+const (
+	// SeverityError rules fail validation, so invalid targets are replaced with default values.
+	SeverityError Severity = iota
+	// SeverityWarn rules are advisory: failures are logged and reported as warnings.
+	SeverityWarn
+	// SeverityInfo rules are advisory: failures are logged and reported as notices.
+	SeverityInfo
+)
+
+var severityNames = []string{"error", "warn", "info"}
+
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(severityNames) {
+		return nil, fmt.Errorf("invalid severity '%d'", int(s))
+	}
+	return []byte(severityNames[s]), nil
+}
+
+func (s *Severity) UnmarshalText(text []byte) error {
+	for i, name := range severityNames {
+		if string(text) == name {
+			*s = Severity(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid severity '%s' (severity should be in ['error','warn','info'])", text)
+}
+
+// advisory is set of rules with severity lower than error.
+type advisory struct {
+	severity Severity
+	rules    Configurator
+}
+
+// WithSeverityRules provides rules of severity, e.g. warning about unusual timeout without rejecting it:
+//
+//	NewConfigurator().WithMax(time.Hour).WithSeverityRules(SeverityWarn, NewConfigurator().WithMax(5*time.Minute))
+//
+// Rules with SeverityError are validators of target. Other rules are checked with configured value:
+// failed rules are logged with their severity and returned by ConfigureWithReport and Explain as warnings.
+func (c Configurator) WithSeverityRules(severity Severity, rules Configurator) Configurator {
+	if severity == SeverityError {
+		return c.WithValidators(rules)
+	}
+	var result []advisory
+	result = append(result, c.advisories...)
+	result = append(result, advisory{severity: severity, rules: rules})
+	c.advisories = result
+	return c
+}
+
+// advise checks advisory rules with target and returns failed rules. Unset optional values are not checked.
+func (c Configurator) advise(target interface{}) []RuleReport {
+	if c.isOptional && isNil(target) {
+		return nil
+	}
+	var warnings []RuleReport
+	for _, advisory := range c.advisories {
+		rules, err := advisory.rules.inherit(c).convert(target)
+		if err != nil {
+			warnings = append(warnings, RuleReport{Severity: advisory.severity, Rule: "rules", Reason: err.Error()})
+			continue
+		}
+		var report Report
+		rules.evaluate(target, &report)
+		for _, rule := range report.Rules {
+			if !rule.Passed {
+				rule.Severity = advisory.severity
+				warnings = append(warnings, rule)
+			}
+		}
+	}
+	return warnings
+}
+
+// warn logs failed advisory rules.
+func (c Configurator) warn(warnings []RuleReport) {
+	if c.logFn == nil || c.isDryRun {
+		return
+	}
+	for _, warning := range warnings {
+		if name := c.qualifiedName(); len(name) != 0 {
+			c.print(warning.Severity, "configuration of '%v': %v", name, warning.String())
+		} else {
+			c.print(warning.Severity, "configuration: %v", warning.String())
+		}
+	}
+}
+
+// ConfigureWithReport configures target like Configure and returns report with input, output and warnings of advisory rules.
+// Values are formatted like Explain formats them.
+func (c Configurator) ConfigureWithReport(targetPointer interface{}) (Report, error) {
+	report := Report{Name: c.qualifiedName()}
+	err := c.configureWithReport(targetPointer, &report)
+	if err != nil {
+		report.Error = err.Error()
+	}
+	return report, err
+}
@@ -0,0 +1,98 @@
+package configuring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestConfigurator_WithSeverityRules(t *testing.T) {
+	var messages []string
+	timeout := 10 * time.Minute
+	err := NewConfigurator().WithLogger(func(ctx context.Context, severity Severity, format string, args ...interface{}) {
+		messages = append(messages, severity.String()+": "+fmt.Sprintf(format, args...))
+	}).WithName("Timeout").WithMax(time.Hour).WithDefault(time.Minute).
+		WithSeverityRules(SeverityWarn, NewConfigurator().WithMax("5m")).
+		WithSeverityRules(SeverityInfo, NewConfigurator().WithDisallowed(10*time.Minute)).
+		Configure(&timeout)
+	if err != nil || timeout != 10*time.Minute {
+		t.Errorf("expected '%v', was '%v' ('%v')", 10*time.Minute, timeout, err)
+	}
+	expected := []string{
+		"info: configuration of 'Timeout': max: '1h0m0s' default: '1m0s' input: '10m0s' output: '10m0s'",
+		"warn: configuration of 'Timeout': warn max '5m0s': failed: argument should be lower than or equal to '5m0s'",
+		"info: configuration of 'Timeout': info disallowed ['10m0s']: failed: argument should not be in disallowed values ['10m0s']",
+	}
+	if fmt.Sprint(messages) != fmt.Sprint(expected) {
+		t.Errorf("expected '%v', was '%v'", expected, messages)
+	}
+	messages = nil
+	err = NewConfigurator().WithLogger(func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}).WithSeverityRules(SeverityWarn, NewConfigurator().WithMax(5*time.Minute)).Configure(&timeout)
+	expected = []string{
+		"configuration: input: '10m0s' output: '10m0s'",
+		"configuration: warn max '5m0s': failed: argument should be lower than or equal to '5m0s'",
+	}
+	if err != nil || fmt.Sprint(messages) != fmt.Sprint(expected) {
+		t.Errorf("expected '%v', was '%v' ('%v')", expected, messages, err)
+	}
+	err = NewConfigurator().WithSeverityRules(SeverityError, NewConfigurator().WithMax(5*time.Minute)).WithDefault(time.Minute).Configure(&timeout)
+	if err != nil || timeout != time.Minute {
+		t.Errorf("expected '%v', was '%v' ('%v')", time.Minute, timeout, err)
+	}
+	err = NewConfigurator().WithSeverityRules(SeverityWarn, NewConfigurator().WithMax(false)).Configure(&timeout)
+	if err != nil || timeout != time.Minute {
+		t.Errorf("expected '%v', was '%v' ('%v')", time.Minute, timeout, err)
+	}
+}
+
+func TestConfigurator_ConfigureWithReport(t *testing.T) {
+	timeout := 10 * time.Minute
+	report, err := NewConfigurator().WithName("Timeout").WithMax(time.Hour).
+		WithSeverityRules(SeverityWarn, NewConfigurator().WithMax(5*time.Minute)).
+		WithSeverityRules(SeverityInfo, NewConfigurator().WithMax(time.Hour)).
+		ConfigureWithReport(&timeout)
+	if err != nil || timeout != 10*time.Minute {
+		t.Errorf("expected '%v', was '%v' ('%v')", 10*time.Minute, timeout, err)
+	}
+	expected := `configuration of 'Timeout':
+  input: '10m0s'
+  warn max '5m0s': failed: argument should be lower than or equal to '5m0s'
+  output: '10m0s'`
+	if result := report.String(); result != expected {
+		t.Errorf("expected '%v', was '%v'", expected, result)
+	}
+	data, err := json.Marshal(report.Warnings)
+	expectedJSON := `[{"severity":"warn","rule":"max","value":"'5m0s'","passed":false,"reason":"argument should be lower than or equal to '5m0s'"}]`
+	if err != nil || string(data) != expectedJSON {
+		t.Errorf("expected '%v', was '%v' ('%v')", expectedJSON, string(data), err)
+	}
+	report, err = NewConfigurator().WithMax(time.Minute).ConfigureWithReport(&timeout)
+	if err == nil || report.Error != "configuration error: target value error: argument should be lower than or equal to '1m0s'" {
+		t.Errorf("expected '%v', was '%v'", "configuration error: target value error: argument should be lower than or equal to '1m0s'", report.Error)
+	}
+	if report.Input != "'10m0s'" || report.Output != "" {
+		t.Errorf("expected '%v', was '%v'", "'10m0s'", report.Input)
+	}
+	report = NewConfigurator().WithSeverityRules(SeverityWarn, NewConfigurator().WithMax(5*time.Minute)).Explain(&timeout)
+	if len(report.Warnings) != 1 || report.Warnings[0].String() != "warn max '5m0s': failed: argument should be lower than or equal to '5m0s'" {
+		t.Errorf("expected '%v', was '%v'", "warn max '5m0s': failed: argument should be lower than or equal to '5m0s'", report.Warnings)
+	}
+}
+
+func TestSeverity_UnmarshalText(t *testing.T) {
+	var severity Severity
+	if err := json.Unmarshal([]byte(`"warn"`), &severity); err != nil || severity != SeverityWarn {
+		t.Errorf("expected '%v', was '%v' ('%v')", SeverityWarn, severity, err)
+	}
+	err := severity.UnmarshalText([]byte("fatal"))
+	if err == nil || err.Error() != "invalid severity 'fatal' (severity should be in ['error','warn','info'])" {
+		t.Errorf("expected '%v', was '%v'", "invalid severity 'fatal' (severity should be in ['error','warn','info'])", err)
+	}
+	if result := Severity(5).String(); result != "severity(5)" {
+		t.Errorf("expected '%v', was '%v'", "severity(5)", result)
+	}
+}
@@ -0,0 +1,178 @@
+package configuring
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ValidatorFunc is validator function.
+type ValidatorFunc func(target interface{}) error
+
+func (fn ValidatorFunc) Validate(target interface{}) error {
+	return fn(target)
+}
+
+type allValidator struct {
+	validators []Validator
+}
+
+// All returns validator passing if target is validated with all validators.
+// Its error contains errors of all failed validators.
+func All(validators ...Validator) Validator {
+	return allValidator{validators: validators}
+}
+
+func (v allValidator) Validate(target interface{}) error {
+	return v.ValidateContext(context.Background(), target)
+}
+
+func (v allValidator) ValidateContext(ctx context.Context, target interface{}) error {
+	var messages []string
+	for i, validator := range v.validators {
+		if err := validateContext(ctx, validator, target); err != nil {
+			messages = append(messages, fmt.Sprintf("validator at index '%v': %v", i, err))
+		}
+	}
+	if len(messages) > 0 {
+		return fmt.Errorf("%v of %v validators failed: %v", len(messages), len(v.validators), strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+type anyValidator struct {
+	validators []Validator
+}
+
+// Any returns validator passing if target is validated with any of validators.
+// Its error contains errors of all validators.
+func Any(validators ...Validator) Validator {
+	return anyValidator{validators: validators}
+}
+
+func (v anyValidator) Validate(target interface{}) error {
+	return v.ValidateContext(context.Background(), target)
+}
+
+func (v anyValidator) ValidateContext(ctx context.Context, target interface{}) error {
+	messages := make([]string, 0, len(v.validators))
+	for i, validator := range v.validators {
+		err := validateContext(ctx, validator, target)
+		if err == nil {
+			return nil
+		}
+		messages = append(messages, fmt.Sprintf("alternative at index '%v': %v", i, err))
+	}
+	if len(messages) == 0 {
+		return fmt.Errorf("none of %v alternatives matched", len(v.validators))
+	}
+	return fmt.Errorf("none of %v alternatives matched: %v", len(v.validators), strings.Join(messages, "; "))
+}
+
+type notValidator struct {
+	validator Validator
+}
+
+// Not returns validator passing if target is not validated with validator.
+// Its error contains target unless target is validated by secret configurator.
+func Not(validator Validator) Validator {
+	return notValidator{validator: validator}
+}
+
+func (v notValidator) Validate(target interface{}) error {
+	return v.ValidateContext(context.Background(), target)
+}
+
+func (v notValidator) ValidateContext(ctx context.Context, target interface{}) error {
+	if err := validateContext(ctx, v.validator, target); err == nil {
+		return fmt.Errorf("argument '%v' should not be validated with validator", displayTarget(ctx, target))
+	}
+	return ctx.Err()
+}
+
+type whenValidator struct {
+	predicate func(target interface{}) bool
+	validator Validator
+}
+
+// When returns validator validating target with validator only if predicate is true for target.
+func When(predicate func(target interface{}) bool, validator Validator) Validator {
+	return whenValidator{predicate: predicate, validator: validator}
+}
+
+func (v whenValidator) Validate(target interface{}) error {
+	return v.ValidateContext(context.Background(), target)
+}
+
+func (v whenValidator) ValidateContext(ctx context.Context, target interface{}) error {
+	if !v.predicate(target) {
+		return nil
+	}
+	return validateContext(ctx, v.validator, target)
+}
+
+// OrEmpty returns validator passing if target is empty (nil, zero value or value of zero length)
+// or target is validated with validator.
+// It is not named Optional, since Optional is the type of optional values (see Optional[T]).
+func OrEmpty(validator Validator) Validator {
+	return When(func(target interface{}) bool {
+		return !isEmptyValue(target)
+	}, validator)
+}
+
+func isEmptyValue(target interface{}) bool {
+	rTarget := reflect.ValueOf(target)
+	switch rTarget.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return rTarget.Len() == 0
+	case reflect.Func, reflect.Interface, reflect.Ptr:
+		return rTarget.IsNil()
+	}
+	return reflect.DeepEqual(target, reflect.Zero(rTarget.Type()).Interface())
+}
+
+var validatorRegistry = struct {
+	sync.RWMutex
+	validators map[string]Validator
+}{
+	validators: map[string]Validator{},
+}
+
+// RegisterValidator registers validator by name for all configurators (see NamedValidator).
+// Registered validator replaces previously registered validator of the same name.
+func RegisterValidator(name string, validator Validator) {
+	validatorRegistry.Lock()
+	defer validatorRegistry.Unlock()
+	validatorRegistry.validators[name] = validator
+}
+
+func findValidator(name string) (Validator, bool) {
+	validatorRegistry.RLock()
+	defer validatorRegistry.RUnlock()
+	validator, found := validatorRegistry.validators[name]
+	return validator, found
+}
+
+type namedValidator string
+
+// NamedValidator returns validator validating target with validator registered by name with RegisterValidator on validation.
+// Named validators are serialized by names in rule sets (see Configurator.MarshalJSON).
+func NamedValidator(name string) Validator {
+	return namedValidator(name)
+}
+
+func (v namedValidator) Validate(target interface{}) error {
+	return v.ValidateContext(context.Background(), target)
+}
+
+func (v namedValidator) ValidateContext(ctx context.Context, target interface{}) error {
+	validator, found := findValidator(string(v))
+	if !found {
+		return fmt.Errorf("validator '%v' should be registered", string(v))
+	}
+	return validateContext(ctx, validator, target)
+}
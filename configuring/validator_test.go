@@ -0,0 +1,107 @@
+package configuring
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var testURLValidator = ValidatorFunc(func(target interface{}) error {
+	value, _ := target.(string)
+	if parsedURL, err := url.Parse(value); err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+		return errors.New("argument should be absolute url")
+	}
+	return nil
+})
+
+func TestAll(t *testing.T) {
+	validator := All(NewConfigurator().WithMin(1), NewConfigurator().WithMax(10), NewConfigurator().WithDisallowed(5))
+	err := validator.Validate(3)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithValidators(validator).Validate(0)
+	if err == nil || err.Error() != "validation error: argument should be validated with validator at index '0': 1 of 3 validators failed: validator at index '0': validation error: argument should be greater than or equal to '1'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be validated with validator at index '0': 1 of 3 validators failed: validator at index '0': validation error: argument should be greater than or equal to '1'", err)
+	}
+	err = All(NewConfigurator().WithMin(10), NewConfigurator().WithMax(10), NewConfigurator().WithDisallowed(11)).Validate(11)
+	if err == nil || err.Error() != "2 of 3 validators failed: validator at index '1': validation error: argument should be lower than or equal to '10'; validator at index '2': validation error: argument should not be in disallowed values ['11']" {
+		t.Errorf("expected '%v', was '%v'", "2 of 3 validators failed: validator at index '1': validation error: argument should be lower than or equal to '10'; validator at index '2': validation error: argument should not be in disallowed values ['11']", err)
+	}
+}
+
+func TestAny(t *testing.T) {
+	validator := Any(NewConfigurator().WithAllowed(""), testURLValidator)
+	err := validator.Validate("https://example.com")
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = validator.Validate("")
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = Any(NewConfigurator().WithAllowed(""), testURLValidator, NewConfigurator().WithAllowed("localhost")).Validate("example")
+	if err == nil || err.Error() != "none of 3 alternatives matched: alternative at index '0': validation error: argument should be in allowed values ['']; alternative at index '1': argument should be absolute url; alternative at index '2': validation error: argument should be in allowed values ['localhost']" {
+		t.Errorf("expected '%v', was '%v'", "none of 3 alternatives matched: alternative at index '0': validation error: argument should be in allowed values ['']; alternative at index '1': argument should be absolute url; alternative at index '2': validation error: argument should be in allowed values ['localhost']", err)
+	}
+	err = Any().Validate(1)
+	if err == nil || err.Error() != "none of 0 alternatives matched" {
+		t.Errorf("expected '%v', was '%v'", "none of 0 alternatives matched", err)
+	}
+}
+
+func TestNot(t *testing.T) {
+	validator := Not(ValidatorFunc(func(target interface{}) error {
+		if strings.HasPrefix(target.(string), "http://") {
+			return nil
+		}
+		return errors.New("argument should be insecure url")
+	}))
+	err := validator.Validate("https://example.com")
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = validator.Validate("http://example.com")
+	if err == nil || err.Error() != "argument 'http://example.com' should not be validated with validator" {
+		t.Errorf("expected '%v', was '%v'", "argument 'http://example.com' should not be validated with validator", err)
+	}
+	err = NewConfigurator().Secret().WithValidators(All(validator)).Validate("http://example.com")
+	if err == nil || err.Error() != "validation error: argument should be validated with validator at index '0': 1 of 1 validators failed: validator at index '0': argument '*secret*' should not be validated with validator" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be validated with validator at index '0': 1 of 1 validators failed: validator at index '0': argument '*secret*' should not be validated with validator", err)
+	}
+	err = NewConfigurator().Secret().WithElementValidators(validator).Validate([]string{"http://example.com"})
+	if err == nil || err.Error() != "validation error: argument element at index '0' should be validated with validator at index '0': argument '*secret*' should not be validated with validator" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument element at index '0' should be validated with validator at index '0': argument '*secret*' should not be validated with validator", err)
+	}
+}
+
+func TestWhen(t *testing.T) {
+	validator := When(func(target interface{}) bool {
+		return target.(int) > 0
+	}, NewConfigurator().WithMax(10))
+	err := validator.Validate(-100)
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = validator.Validate(100)
+	if err == nil || err.Error() != "validation error: argument should be lower than or equal to '10'" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument should be lower than or equal to '10'", err)
+	}
+}
+
+func TestOrEmpty(t *testing.T) {
+	err := NewConfigurator().WithElementValidators(OrEmpty(testURLValidator)).Validate([]string{"", "https://example.com"})
+	if err != nil {
+		t.Errorf("expected '%v', was '%v'", error(nil), err)
+	}
+	err = NewConfigurator().WithElementValidators(OrEmpty(testURLValidator)).Validate([]string{"", "example"})
+	if err == nil || err.Error() != "validation error: argument element at index '1' should be validated with validator at index '0': argument should be absolute url" {
+		t.Errorf("expected '%v', was '%v'", "validation error: argument element at index '1' should be validated with validator at index '0': argument should be absolute url", err)
+	}
+	for _, target := range []interface{}{nil, 0, "", []int{}, (*int)(nil), struct{ A int }{}} {
+		if err := OrEmpty(ValidatorFunc(func(interface{}) error { return errors.New("invalid") })).Validate(target); err != nil {
+			t.Errorf("expected '%v', was '%v'", error(nil), err)
+		}
+	}
+}